@@ -17,6 +17,7 @@ import (
 	"crypto/x509"
 	"encoding/pem"
 	"fmt"
+	"log/slog"
 	"sync"
 
 	"filippo.io/edwards25519"
@@ -84,6 +85,78 @@ func getServerPubKey(name string) (pubKey *rsa.PublicKey) {
 	return
 }
 
+// AuthPlugin is the client side of an authentication method of the server.
+// Plugins registered with RegisterAuthPlugin take precedence over the
+// built-in ones.
+type AuthPlugin interface {
+	// Begin starts the authentication of a connection. scramble is the auth
+	// plugin data sent by the server in the handshake or in an auth switch
+	// request. Begin returns the first auth response and the exchange which
+	// handles the following rounds of this authentication, if any.
+	Begin(scramble []byte, cfg *Config) (AuthExchange, []byte, error)
+}
+
+// AuthExchange is the state of an authentication of a single connection.
+type AuthExchange interface {
+	// Next is called with the payload of each AuthMoreData packet sent by
+	// the server and returns the response. A nil response sends nothing and
+	// waits for the next packet of the server. data is only valid until
+	// Next returns.
+	Next(data []byte) ([]byte, error)
+}
+
+// AuthCompleter is implemented by an AuthExchange which must check that the
+// exchange is complete when the server accepts the authentication, e.g.
+// that the server has authenticated itself.
+type AuthCompleter interface {
+	// Done is called when the server sends OK. An error fails the
+	// authentication.
+	Done() error
+}
+
+// auth plugins registry
+var (
+	authPluginsLock sync.RWMutex
+	authPlugins     = map[string]AuthPlugin{
+		"authentication_ldap_sasl_client": saslAuthPlugin{},
+	}
+)
+
+// RegisterAuthPlugin registers an authentication plugin under the name the
+// server uses for it, e.g. "authentication_kerberos_client". The plugin is
+// used when the server requests this authentication method, either in the
+// handshake or with an auth switch request.
+//
+//	mysql.RegisterAuthPlugin("mysql_iam_token", tokenPlugin{})
+func RegisterAuthPlugin(name string, plugin AuthPlugin) {
+	authPluginsLock.Lock()
+	if authPlugins == nil {
+		authPlugins = make(map[string]AuthPlugin)
+	}
+
+	authPlugins[name] = plugin
+	authPluginsLock.Unlock()
+}
+
+// DeregisterAuthPlugin removes the authentication plugin registered with the
+// given name.
+func DeregisterAuthPlugin(name string) {
+	authPluginsLock.Lock()
+	if authPlugins != nil {
+		delete(authPlugins, name)
+	}
+	authPluginsLock.Unlock()
+}
+
+func getAuthPlugin(name string) (plugin AuthPlugin) {
+	authPluginsLock.RLock()
+	if v, ok := authPlugins[name]; ok {
+		plugin = v
+	}
+	authPluginsLock.RUnlock()
+	return
+}
+
 // Hash password using pre 4.1 (old password) method
 // https://github.com/atcurtis/mariadb/blob/master/mysys/my_rnd.c
 type myRnd struct {
@@ -275,6 +348,18 @@ func (mc *mysqlConn) sendEncryptedPassword(seed []byte, pub *rsa.PublicKey) erro
 }
 
 func (mc *mysqlConn) auth(authData []byte, plugin string) ([]byte, error) {
+	mc.authExchange = nil
+	if p := getAuthPlugin(plugin); p != nil {
+		// copy data from read buffer to owned slice
+		scramble := append([]byte(nil), authData...)
+		exchange, authResp, err := p.Begin(scramble, mc.cfg)
+		if err != nil {
+			return nil, err
+		}
+		mc.authExchange = exchange
+		return authResp, nil
+	}
+
 	switch plugin {
 	case "caching_sha2_password":
 		authResp := scrambleSHA256Password(authData, mc.cfg.Passwd)
@@ -294,7 +379,10 @@ func (mc *mysqlConn) auth(authData []byte, plugin string) ([]byte, error) {
 		return authResp, nil
 
 	case "mysql_clear_password":
-		if !mc.cfg.AllowCleartextPasswords {
+		// Provided passwords, e.g. IAM tokens, may be sent over a secure
+		// connection.
+		secure := mc.cfg.TLS != nil || mc.cfg.Net == "unix"
+		if !mc.cfg.AllowCleartextPasswords && !(mc.cfg.passwords != nil && secure) {
 			return nil, ErrCleartextPassword
 		}
 		// http://dev.mysql.com/doc/refman/5.7/en/cleartext-authentication-plugin.html
@@ -338,7 +426,7 @@ func (mc *mysqlConn) auth(authData []byte, plugin string) ([]byte, error) {
 		return authEd25519(authData, mc.cfg.Passwd)
 
 	default:
-		mc.log("unknown auth plugin:", plugin)
+		mc.logEvent(slog.LevelWarn, "unknown auth plugin", nil, slog.String("plugin", plugin))
 		return nil, ErrUnknownPlugin
 	}
 }
@@ -362,6 +450,7 @@ func (mc *mysqlConn) handleAuthResult(oldAuthData []byte, plugin string) error {
 		}
 
 		plugin = newPlugin
+		mc.logEvent(slog.LevelDebug, "switching auth plugin", nil, slog.String("plugin", plugin))
 
 		authResp, err := mc.auth(authData, plugin)
 		if err != nil {
@@ -383,6 +472,10 @@ func (mc *mysqlConn) handleAuthResult(oldAuthData []byte, plugin string) error {
 		}
 	}
 
+	if getAuthPlugin(plugin) != nil {
+		return mc.continueAuth(authData)
+	}
+
 	switch plugin {
 
 	// https://dev.mysql.com/blog-archive/preparing-your-community-connector-for-mysql-8-part-2-sha256/
@@ -482,3 +575,39 @@ func (mc *mysqlConn) handleAuthResult(oldAuthData []byte, plugin string) error {
 
 	return err
 }
+
+// continueAuth runs the exchange of a registered auth plugin until the server
+// accepts or rejects the authentication. data is the payload of the
+// AuthMoreData packet sent by the server, or nil if the server sent OK.
+func (mc *mysqlConn) continueAuth(data []byte) error {
+	exchange := mc.authExchange
+	mc.authExchange = nil
+
+	for data != nil {
+		if exchange == nil {
+			return ErrMalformPkt
+		}
+		resp, err := exchange.Next(data)
+		if err != nil {
+			return err
+		}
+		if resp != nil {
+			if err = mc.writeAuthSwitchPacket(resp); err != nil {
+				return err
+			}
+		}
+
+		var newPlugin string
+		data, newPlugin, err = mc.readAuthResult()
+		if err != nil {
+			return err
+		}
+		if newPlugin != "" {
+			return ErrMalformPkt
+		}
+	}
+	if c, ok := exchange.(AuthCompleter); ok {
+		return c.Done()
+	}
+	return nil
+}
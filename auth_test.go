@@ -1381,3 +1381,106 @@ func TestEd25519Auth(t *testing.T) {
 		t.Errorf("got error: %v", err)
 	}
 }
+
+// testAuthPlugin answers each AuthMoreData packet with its payload in upper
+// case, and sends nothing for "-".
+type testAuthPlugin struct{}
+
+type testAuthExchange struct {
+	scramble []byte
+	rounds   int
+}
+
+func (testAuthPlugin) Begin(scramble []byte, cfg *Config) (AuthExchange, []byte, error) {
+	return &testAuthExchange{scramble: scramble}, []byte(cfg.Passwd), nil
+}
+
+func (e *testAuthExchange) Next(data []byte) ([]byte, error) {
+	e.rounds++
+	if string(data) == "-" {
+		return nil, nil
+	}
+	return bytes.ToUpper(data), nil
+}
+
+func TestAuthRegisteredPluginMultiRound(t *testing.T) {
+	RegisterAuthPlugin("test_plugin", testAuthPlugin{})
+	defer DeregisterAuthPlugin("test_plugin")
+
+	conn, mc := newRWMockConn(2)
+	mc.cfg.Passwd = "secret"
+
+	authResp, err := mc.auth([]byte{1, 2, 3}, "test_plugin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(authResp) != "secret" {
+		t.Fatalf("unexpected auth response %q", authResp)
+	}
+	exchange := mc.authExchange.(*testAuthExchange)
+	if !bytes.Equal(exchange.scramble, []byte{1, 2, 3}) {
+		t.Errorf("unexpected scramble %v", exchange.scramble)
+	}
+
+	conn.data = makePackets(2, []byte{iAuthMoreData, 'a', 'b'})
+	conn.queuedReplies = [][]byte{
+		makePackets(4, []byte{iAuthMoreData, '-'}, okPacket),
+	}
+	conn.maxReads = 2
+
+	if err := mc.handleAuthResult(nil, "test_plugin"); err != nil {
+		t.Fatal(err)
+	}
+	if expected := makePackets(3, []byte("AB")); !bytes.Equal(conn.written, expected) {
+		t.Errorf("expected %v, got %v", expected, conn.written)
+	}
+	if exchange.rounds != 2 {
+		t.Errorf("expected 2 rounds, got %d", exchange.rounds)
+	}
+	if mc.authExchange != nil {
+		t.Error("auth exchange was not cleared")
+	}
+}
+
+func TestAuthSwitchToRegisteredPlugin(t *testing.T) {
+	RegisterAuthPlugin("test_plugin", testAuthPlugin{})
+	defer DeregisterAuthPlugin("test_plugin")
+
+	conn, mc := newRWMockConn(2)
+	mc.cfg.Passwd = "secret"
+
+	authSwitch := append([]byte{iEOF}, "test_plugin\x00"...)
+	authSwitch = append(authSwitch, 9, 9, 9, 0)
+	conn.data = makePackets(2, authSwitch)
+	conn.queuedReplies = [][]byte{
+		makePackets(4, []byte{iAuthMoreData, 'x'}),
+		makePackets(6, okPacket),
+	}
+	conn.maxReads = 3
+
+	if err := mc.handleAuthResult([]byte{1, 2, 3, 4}, "mysql_native_password"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := append(makePackets(3, []byte("secret")), makePackets(5, []byte("X"))...)
+	if !bytes.Equal(conn.written, expected) {
+		t.Errorf("expected %v, got %v", expected, conn.written)
+	}
+}
+
+func TestAuthRegisteredPluginOverridesBuiltin(t *testing.T) {
+	RegisterAuthPlugin("mysql_clear_password", testAuthPlugin{})
+	defer DeregisterAuthPlugin("mysql_clear_password")
+
+	_, mc := newRWMockConn(1)
+	mc.cfg.Passwd = "secret"
+
+	// the built-in plugin would fail as cleartext passwords are not allowed
+	authResp, err := mc.auth(nil, "mysql_clear_password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(authResp) != "secret" {
+		t.Errorf("unexpected auth response %q", authResp)
+	}
+}
@@ -0,0 +1,211 @@
+// Go MySQL Driver - A MySQL-Driver for Go's database/sql package
+//
+// Copyright 2024 The Go-MySQL-Driver Authors. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package mysql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var errBatchInterpolation = errors.New("arguments can not be interpolated: wrong number of arguments, unsupported type or statement larger than max_allowed_packet")
+
+// Batch is a list of statements which Conn.ExecBatch pipelines to the
+// server.
+type Batch struct {
+	stmts []batchStmt
+}
+
+type batchStmt struct {
+	query string
+	args  []any
+}
+
+// Queue adds a statement to the batch. The placeholders of query are
+// replaced by args, which are escaped like with interpolateParams=true.
+func (b *Batch) Queue(query string, args ...any) {
+	b.stmts = append(b.stmts, batchStmt{query: query, args: args})
+}
+
+// Len returns the number of statements in the batch.
+func (b *Batch) Len() int {
+	return len(b.stmts)
+}
+
+// BatchResult is the result of a statement of a Batch.
+type BatchResult struct {
+	Result Result // nil if the statement failed
+	Err    error  // the server error of the statement, e.g. a *MySQLError
+}
+
+// ExecBatch implements Conn interface.
+func (mc *mysqlConn) ExecBatch(ctx context.Context, b *Batch) ([]BatchResult, error) {
+	if mc.closed.Load() {
+		return nil, driver.ErrBadConn
+	}
+	if len(b.stmts) == 0 {
+		return nil, nil
+	}
+
+	// Interpolate all statements before anything is sent.
+	queries := make([]string, len(b.stmts))
+	for i, stmt := range b.stmts {
+		query, err := mc.interpolateBatchStmt(stmt)
+		if err != nil {
+			return nil, fmt.Errorf("batch statement %d: %w", i, err)
+		}
+		queries[i] = query
+	}
+
+	if err := mc.watchCancel(ctx); err != nil {
+		return nil, err
+	}
+	defer mc.finish()
+	mc.queryAttrs = queryAttributes(ctx)
+	defer func() { mc.queryAttrs = nil }()
+
+	dones := make([]func(driver.Result, error), len(queries))
+	for i, stmt := range b.stmts {
+		dones[i] = mc.traceQuery(ctx, stmt.query, len(stmt.args))
+	}
+	return mc.runBatch(queries, func(i int) error {
+		return mc.writeQueryPacket(queries[i])
+	}, dones)
+}
+
+// runBatch pipelines the commands for queries, which are sent with write,
+// and reads their results in order, discarding their rows.
+func (mc *mysqlConn) runBatch(queries []string, write func(i int) error, dones []func(driver.Result, error)) ([]BatchResult, error) {
+	results := make([]BatchResult, len(queries))
+	read := 0
+	err := mc.pipeline(len(queries), write, func(i int) error {
+		mc.sqlText = queries[i]
+		err := mc.skipResult()
+		var mysqlErr *MySQLError
+		if err != nil && !errors.As(err, &mysqlErr) {
+			return err
+		}
+		if err == nil {
+			res := mc.result
+			res.warningCount = mc.warningCount
+			results[i].Result = &res
+		}
+		results[i].Err = err
+		dones[i](results[i].Result, err)
+		read++
+		return nil
+	})
+	if err != nil {
+		for _, done := range dones[read:] {
+			done(nil, err)
+		}
+		return nil, err
+	}
+	return results, nil
+}
+
+// skipResult reads the result of a command and discards its rows.
+func (mc *mysqlConn) skipResult() error {
+	handleOk := mc.clearResult()
+	resLen, err := handleOk.readResultSetHeaderPacket()
+	if err != nil {
+		return err
+	}
+	if resLen > 0 {
+		if err := mc.skipColumns(resLen); err != nil {
+			return err
+		}
+		if err := mc.readUntilEOF(); err != nil {
+			return err
+		}
+	}
+	return handleOk.discardResults()
+}
+
+// interpolateBatchStmt returns the query of stmt with its arguments.
+func (mc *mysqlConn) interpolateBatchStmt(stmt batchStmt) (string, error) {
+	if len(stmt.args) == 0 {
+		return stmt.query, nil
+	}
+	args := make([]driver.Value, len(stmt.args))
+	for i, arg := range stmt.args {
+		v, err := converter{}.ConvertValue(arg)
+		if err != nil {
+			return "", err
+		}
+		args[i] = v
+	}
+	query, err := mc.interpolateParams(stmt.query, args)
+	if err == driver.ErrSkip {
+		return "", errBatchInterpolation
+	}
+	return query, err
+}
+
+// ExecPipelined implements Conn interface.
+func (mc *mysqlConn) ExecPipelined(ctx context.Context, query string, args [][]any) ([]BatchResult, error) {
+	if mc.closed.Load() {
+		return nil, driver.ErrBadConn
+	}
+	if len(args) == 0 {
+		return nil, nil
+	}
+
+	dargs := make([][]driver.Value, len(args))
+	for i, list := range args {
+		dargs[i] = make([]driver.Value, len(list))
+		for j, arg := range list {
+			v, err := converter{}.ConvertValue(arg)
+			if err != nil {
+				return nil, fmt.Errorf("execution %d: %w", i, err)
+			}
+			dargs[i][j] = v
+		}
+	}
+
+	if err := mc.watchCancel(ctx); err != nil {
+		return nil, err
+	}
+	defer mc.finish()
+
+	start := time.Now()
+	ds, err := mc.Prepare(query)
+	mc.tracePrepare(ctx, query, start, err)
+	if err != nil {
+		return nil, err
+	}
+	stmt := ds.(*mysqlStmt)
+	defer stmt.Close()
+	for i, list := range dargs {
+		if len(list) != stmt.paramCount {
+			return nil, fmt.Errorf("execution %d: argument count mismatch (got: %d; has: %d)", i, len(list), stmt.paramCount)
+		}
+	}
+
+	mc.queryAttrs = queryAttributes(ctx)
+	defer func() { mc.queryAttrs = nil }()
+	return stmt.execPipeline(ctx, dargs)
+}
+
+// execPipeline pipelines an execute packet for each of the argument lists
+// and reads their results in order.
+func (stmt *mysqlStmt) execPipeline(ctx context.Context, args [][]driver.Value) ([]BatchResult, error) {
+	mc := stmt.mc
+	dones := make([]func(driver.Result, error), len(args))
+	queries := make([]string, len(args))
+	for i, list := range args {
+		dones[i] = mc.traceQuery(ctx, stmt.sql, len(list))
+		queries[i] = stmt.sql
+	}
+	return mc.runBatch(queries, func(i int) error {
+		return stmt.writeExecutePacket(args[i], cursorTypeNoCursor)
+	}, dones)
+}
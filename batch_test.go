@@ -0,0 +1,308 @@
+// Go MySQL Driver - A MySQL-Driver for Go's database/sql package
+//
+// Copyright 2024 The Go-MySQL-Driver Authors. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package mysql
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExecBatch(t *testing.T) {
+	tracer := new(recordingTracer)
+	conn, mc := newRWMockConn(0)
+	mc.cfg.tracer = tracer
+	dupEntry := append([]byte{iERR, 0x26, 0x04, '#', '2', '3', '0', '0', '0'}, "Duplicate entry '1' for key 'PRIMARY'"...)
+	conn.queuedReplies = pipelineReplies(
+		makePackets(1, []byte{iOK, 0x01, 0x05, 0x02, 0x00, 0x00, 0x00}),
+		makePackets(1, dupEntry),
+		makePackets(1, []byte{iOK, 0x03, 0x00, 0x02, 0x00, 0x01, 0x00}),
+	)
+
+	var b Batch
+	b.Queue("INSERT INTO t VALUES (?, ?)", 5, "it's")
+	b.Queue("INSERT INTO t VALUES (?, ?)", 1, nil)
+	b.Queue("UPDATE t SET b = ?", []byte("x"))
+	results, err := mc.ExecBatch(context.Background(), &b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("got %d results", len(results))
+	}
+
+	for _, want := range []string{
+		`INSERT INTO t VALUES (5, 'it\'s')`,
+		"INSERT INTO t VALUES (1, NULL)",
+		"UPDATE t SET b = _binary'x'",
+	} {
+		if !bytes.Contains(conn.written, []byte(want)) {
+			t.Errorf("%q was not sent", want)
+		}
+	}
+
+	if err := results[0].Err; err != nil {
+		t.Errorf("statement 0 failed: %v", err)
+	} else if id, _ := results[0].Result.LastInsertId(); id != 5 {
+		t.Errorf("got insert id %d, want 5", id)
+	}
+	if !IsDuplicateKey(results[1].Err) || results[1].Result != nil {
+		t.Errorf("expected duplicate entry, got %v", results[1].Err)
+	}
+	if err := results[2].Err; err != nil {
+		t.Errorf("statement 2 failed: %v", err)
+	} else if n, _ := results[2].Result.RowsAffected(); n != 3 || results[2].Result.WarningCount() != 1 {
+		t.Errorf("got %d affected rows and %d warnings, want 3 and 1", n, results[2].Result.WarningCount())
+	}
+
+	var queries []string
+	var errs []error
+	for _, info := range tracer.done {
+		queries = append(queries, info.Query)
+		errs = append(errs, info.Err)
+	}
+	want := []string{"INSERT INTO t VALUES (?, ?)", "INSERT INTO t VALUES (?, ?)", "UPDATE t SET b = ?"}
+	if !reflect.DeepEqual(queries, want) {
+		t.Errorf("got traced queries %q, want %q", queries, want)
+	}
+	if errs[0] != nil || errs[1] != results[1].Err || errs[2] != nil {
+		t.Errorf("got traced errors %v", errs)
+	}
+}
+
+func TestExecBatchInterpolationError(t *testing.T) {
+	conn, mc := newRWMockConn(0)
+
+	var b Batch
+	b.Queue("INSERT INTO t VALUES (?)", 1)
+	b.Queue("INSERT INTO t VALUES (?, ?)", 1)
+	if _, err := mc.ExecBatch(context.Background(), &b); !errors.Is(err, errBatchInterpolation) {
+		t.Errorf("expected errBatchInterpolation, got %v", err)
+	}
+	if len(conn.written) != 0 {
+		t.Errorf("statements were sent: %q", conn.written)
+	}
+}
+
+// prepareReply returns the reply to COM_STMT_PREPARE for statement 1 with n
+// parameters and no columns.
+func prepareReply(n int) []byte {
+	payloads := [][]byte{{iOK, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, byte(n), 0x00, 0x00, 0x00, 0x00}}
+	if n > 0 {
+		for i := 0; i < n; i++ {
+			payloads = append(payloads, makeColumnDefinition("?", fieldTypeNULL))
+		}
+		payloads = append(payloads, []byte{iEOF, 0x00, 0x00, 0x02, 0x00})
+	}
+	return makePackets(1, payloads...)
+}
+
+// writtenCommands returns the command bytes of the packets in written.
+func writtenCommands(written []byte) []byte {
+	var cmds []byte
+	for len(written) >= 5 {
+		n := int(written[0]) | int(written[1])<<8 | int(written[2])<<16
+		cmds = append(cmds, written[4])
+		written = written[4+n:]
+	}
+	return cmds
+}
+
+func TestExecPipelined(t *testing.T) {
+	tracer := new(recordingTracer)
+	conn, mc := newRWMockConn(0)
+	mc.cfg.tracer = tracer
+	dupEntry := append([]byte{iERR, 0x26, 0x04, '#', '2', '3', '0', '0', '0'}, "Duplicate entry '2' for key 'PRIMARY'"...)
+	conn.queuedReplies = append([][]byte{prepareReply(1)}, pipelineReplies(
+		makePackets(1, []byte{iOK, 0x01, 0x01, 0x02, 0x00, 0x00, 0x00}),
+		makePackets(1, dupEntry),
+		makePackets(1, []byte{iOK, 0x01, 0x03, 0x02, 0x00, 0x00, 0x00}),
+	)...)
+
+	results, err := mc.ExecPipelined(context.Background(), "INSERT INTO t VALUES (?)", [][]any{{1}, {2}, {3}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []byte{comStmtPrepare, comStmtExecute, comStmtExecute, comStmtExecute, comStmtClose}
+	if cmds := writtenCommands(conn.written); !bytes.Equal(cmds, want) {
+		t.Errorf("got commands %v, want %v", cmds, want)
+	}
+	if len(results) != 3 {
+		t.Fatalf("got %d results", len(results))
+	}
+	for i, id := range []int64{1, 0, 3} {
+		if i == 1 {
+			var me *MySQLError
+			if !errors.As(results[i].Err, &me) || me.Query != "" || !IsDuplicateKey(me) {
+				t.Errorf("execution 1: expected duplicate entry, got %v", results[i].Err)
+			}
+			continue
+		}
+		if results[i].Err != nil {
+			t.Errorf("execution %d failed: %v", i, results[i].Err)
+		} else if got, _ := results[i].Result.LastInsertId(); got != id {
+			t.Errorf("execution %d: got insert id %d, want %d", i, got, id)
+		}
+	}
+	if len(tracer.done) != 3 || tracer.done[1].Err != results[1].Err {
+		t.Errorf("got traced executions %+v", tracer.done)
+	}
+	if !mc.IsValid() {
+		t.Error("connection is not valid after the pipeline")
+	}
+}
+
+func TestExecPipelinedBrokenConn(t *testing.T) {
+	conn, mc := newRWMockConn(0)
+	conn.queuedReplies = append([][]byte{prepareReply(1)}, pipelineReplies(
+		makePackets(1, okPacket),
+		makePackets(7, okPacket), // out of sequence
+		makePackets(1, okPacket),
+	)...)
+
+	_, err := mc.ExecPipelined(context.Background(), "INSERT INTO t VALUES (?)", [][]any{{1}, {2}, {3}})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if mc.IsValid() {
+		t.Error("connection is valid after a broken pipeline")
+	}
+}
+
+func TestExecPipelinedArgumentCount(t *testing.T) {
+	conn, mc := newRWMockConn(0)
+	conn.queuedReplies = [][]byte{prepareReply(1)}
+
+	if _, err := mc.ExecPipelined(context.Background(), "INSERT INTO t VALUES (?)", [][]any{{1}, {2, 3}}); err == nil {
+		t.Fatal("expected argument count mismatch")
+	}
+	if cmds := writtenCommands(conn.written); !bytes.Equal(cmds, []byte{comStmtPrepare, comStmtClose}) {
+		t.Errorf("got commands %v, want prepare and close only", cmds)
+	}
+	if !mc.IsValid() {
+		t.Error("connection is not valid")
+	}
+}
+
+// newTCPConn returns a connection to a server over TCP with small socket
+// buffers, which replies to each command only after its reply to the
+// previous command has been sent.
+func newTCPConn(t *testing.T) *mysqlConn {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skip(err)
+	}
+	defer ln.Close()
+	client, err := net.Dial("tcp", ln.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	server, err := ln.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range []net.Conn{client, server} {
+		c.(*net.TCPConn).SetReadBuffer(4096)
+		c.(*net.TCPConn).SetWriteBuffer(4096)
+		// fail instead of hanging if the pipeline blocks
+		c.SetDeadline(time.Now().Add(10 * time.Second))
+	}
+	t.Cleanup(func() { client.Close() })
+
+	go func() {
+		defer server.Close()
+		header := make([]byte, 4)
+		for {
+			if _, err := io.ReadFull(server, header); err != nil {
+				return
+			}
+			payload := make([]byte, int(header[0])|int(header[1])<<8|int(header[2])<<16)
+			if _, err := io.ReadFull(server, payload); err != nil {
+				return
+			}
+			var reply []byte
+			switch payload[0] {
+			case comStmtPrepare:
+				reply = prepareReply(1)
+			case comStmtClose:
+				continue
+			default:
+				reply = makePackets(1, []byte{iOK, 0x01, 0x00, 0x02, 0x00, 0x00, 0x00})
+			}
+			if _, err := server.Write(reply); err != nil {
+				return
+			}
+		}
+	}()
+
+	connector := newConnector(NewConfig())
+	return &mysqlConn{
+		buf:              newBuffer(client),
+		cfg:              connector.cfg,
+		connector:        connector,
+		netConn:          client,
+		closech:          make(chan struct{}),
+		maxAllowedPacket: defaultMaxAllowedPacket,
+	}
+}
+
+// pipelineSize is the number of statements of the pipelines larger than the
+// socket buffers in both directions.
+const pipelineSize = 5000
+
+func TestExecBatchLargerThanSocketBuffers(t *testing.T) {
+	mc := newTCPConn(t)
+
+	var b Batch
+	value := strings.Repeat("x", 256)
+	for i := 0; i < pipelineSize; i++ {
+		b.Queue("INSERT INTO t VALUES (?, ?)", i, value)
+	}
+	results, err := mc.ExecBatch(context.Background(), &b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != pipelineSize {
+		t.Fatalf("got %d results", len(results))
+	}
+	for i, res := range results {
+		if res.Err != nil {
+			t.Fatalf("statement %d failed: %v", i, res.Err)
+		}
+	}
+}
+
+func TestExecPipelinedLargerThanSocketBuffers(t *testing.T) {
+	mc := newTCPConn(t)
+
+	args := make([][]any, pipelineSize)
+	value := strings.Repeat("x", 256)
+	for i := range args {
+		args[i] = []any{value}
+	}
+	results, err := mc.ExecPipelined(context.Background(), "INSERT INTO t VALUES (?)", args)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != pipelineSize {
+		t.Fatalf("got %d results", len(results))
+	}
+	for i, res := range results {
+		if res.Err != nil {
+			t.Fatalf("execution %d failed: %v", i, res.Err)
+		}
+	}
+}
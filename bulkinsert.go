@@ -0,0 +1,222 @@
+// Go MySQL Driver - A MySQL-Driver for Go's database/sql package
+//
+// Copyright 2024 The Go-MySQL-Driver Authors. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package mysql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var errBulkInsertNoColumns = errors.New("bulk insert without columns")
+
+// RowSource supplies the rows of Conn.BulkInsert.
+type RowSource interface {
+	// NextRow returns the values of the next row in the order of the
+	// columns, or io.EOF after the last row.
+	NextRow() ([]any, error)
+}
+
+// SliceRowSource returns a RowSource which supplies rows.
+func SliceRowSource(rows [][]any) RowSource {
+	return &sliceRowSource{rows: rows}
+}
+
+type sliceRowSource struct {
+	rows [][]any
+}
+
+func (s *sliceRowSource) NextRow() ([]any, error) {
+	if len(s.rows) == 0 {
+		return nil, io.EOF
+	}
+	row := s.rows[0]
+	s.rows = s.rows[1:]
+	return row, nil
+}
+
+// BulkInsertOption configures Conn.BulkInsert.
+type BulkInsertOption func(*bulkInsert)
+
+type bulkInsert struct {
+	ignore    bool
+	upsert    bool
+	updateSet []string
+}
+
+// InsertIgnore inserts the rows with INSERT IGNORE, so that rows which
+// duplicate a unique key are skipped with a warning.
+func InsertIgnore() BulkInsertOption {
+	return func(b *bulkInsert) {
+		b.ignore = true
+	}
+}
+
+// OnDuplicateKeyUpdate updates the given columns of existing rows with the
+// values of inserted rows which duplicate a unique key, with
+// ON DUPLICATE KEY UPDATE. Without columns, all inserted columns are
+// updated.
+func OnDuplicateKeyUpdate(columns ...string) BulkInsertOption {
+	return func(b *bulkInsert) {
+		b.upsert = true
+		b.updateSet = columns
+	}
+}
+
+// prefix returns the INSERT statement up to the VALUES keyword.
+func (b *bulkInsert) prefix(table string, columns []string) string {
+	var sb strings.Builder
+	sb.WriteString("INSERT ")
+	if b.ignore {
+		sb.WriteString("IGNORE ")
+	}
+	sb.WriteString("INTO ")
+	for i, part := range strings.Split(table, ".") {
+		if i > 0 {
+			sb.WriteByte('.')
+		}
+		sb.WriteString(quoteIdentifier(part))
+	}
+	sb.WriteString(" (")
+	for i, column := range columns {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(quoteIdentifier(column))
+	}
+	sb.WriteString(") VALUES ")
+	return sb.String()
+}
+
+// suffix returns the ON DUPLICATE KEY UPDATE clause, if any.
+func (b *bulkInsert) suffix(columns []string) string {
+	if !b.upsert {
+		return ""
+	}
+	update := b.updateSet
+	if len(update) == 0 {
+		update = columns
+	}
+	var sb strings.Builder
+	sb.WriteString(" ON DUPLICATE KEY UPDATE ")
+	for i, column := range update {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		quoted := quoteIdentifier(column)
+		sb.WriteString(quoted + "=VALUES(" + quoted + ")")
+	}
+	return sb.String()
+}
+
+// BulkInsert implements Conn interface.
+func (mc *mysqlConn) BulkInsert(ctx context.Context, table string, columns []string, rows RowSource, opts ...BulkInsertOption) (int64, error) {
+	if mc.closed.Load() {
+		return 0, driver.ErrBadConn
+	}
+	if len(columns) == 0 {
+		return 0, errBulkInsertNoColumns
+	}
+	var b bulkInsert
+	for _, opt := range opts {
+		opt(&b)
+	}
+
+	mc.queryAttrs = queryAttributes(ctx)
+	defer func() { mc.queryAttrs = nil }()
+
+	prefix, suffix := b.prefix(table, columns), b.suffix(columns)
+	// The command byte and the query attributes are part of the packet.
+	limit := mc.maxAllowedPacket - 1 - len(mc.queryAttrParams())
+
+	var affected int64
+	query := []byte(prefix)
+	var tuple []byte
+	numRows := 0
+	for i := 0; ; i++ {
+		row, err := rows.NextRow()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return affected, err
+		}
+		if len(row) != len(columns) {
+			return affected, fmt.Errorf("bulk insert row %d: %d values for %d columns", i, len(row), len(columns))
+		}
+		if tuple, err = mc.appendRow(tuple[:0], row); err != nil {
+			return affected, fmt.Errorf("bulk insert row %d: %w", i, err)
+		}
+		if len(prefix)+len(tuple)+len(suffix) > limit {
+			return affected, fmt.Errorf("bulk insert row %d: %w", i, ErrPktTooLarge)
+		}
+
+		if numRows > 0 && len(query)+1+len(tuple)+len(suffix) > limit {
+			n, err := mc.execBulkInsert(ctx, string(query)+suffix)
+			affected += n
+			if err != nil {
+				return affected, err
+			}
+			query, numRows = query[:len(prefix)], 0
+		}
+		if numRows > 0 {
+			query = append(query, ',')
+		}
+		query = append(query, tuple...)
+		numRows++
+	}
+
+	if numRows > 0 {
+		n, err := mc.execBulkInsert(ctx, string(query)+suffix)
+		affected += n
+		if err != nil {
+			return affected, err
+		}
+	}
+	return affected, nil
+}
+
+// appendRow appends the values of row as a tuple to buf.
+func (mc *mysqlConn) appendRow(buf []byte, row []any) ([]byte, error) {
+	buf = append(buf, '(')
+	for i, arg := range row {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		v, err := converter{}.ConvertValue(arg)
+		if err != nil {
+			return nil, err
+		}
+		if buf, err = mc.appendValue(buf, v); err == driver.ErrSkip {
+			return nil, fmt.Errorf("unsupported type %T", v)
+		} else if err != nil {
+			return nil, err
+		}
+	}
+	return append(buf, ')'), nil
+}
+
+// execBulkInsert executes an INSERT statement of BulkInsert and returns the
+// number of affected rows.
+func (mc *mysqlConn) execBulkInsert(ctx context.Context, query string) (int64, error) {
+	if err := mc.watchCancel(ctx); err != nil {
+		return 0, err
+	}
+	defer mc.finish()
+
+	done := mc.traceQuery(ctx, query, 0)
+	res, err := mc.Exec(query, nil)
+	done(res, err)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
@@ -0,0 +1,138 @@
+// Go MySQL Driver - A MySQL-Driver for Go's database/sql package
+//
+// Copyright 2024 The Go-MySQL-Driver Authors. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package mysql
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestBulkInsertChunks(t *testing.T) {
+	tracer := new(recordingTracer)
+	conn, mc := newRWMockConn(0)
+	mc.cfg.tracer = tracer
+	prefix := "INSERT INTO `db`.`t` (`id`,`name`) VALUES "
+	// room for two rows of up to 8 bytes per statement, plus the command byte
+	mc.maxAllowedPacket = 1 + len(prefix) + 2*8 + 1
+	conn.queuedReplies = [][]byte{
+		makePackets(1, []byte{iOK, 0x02, 0x00, 0x02, 0x00, 0x00, 0x00}),
+		makePackets(1, []byte{iOK, 0x02, 0x00, 0x02, 0x00, 0x00, 0x00}),
+		makePackets(1, []byte{iOK, 0x01, 0x00, 0x02, 0x00, 0x00, 0x00}),
+	}
+
+	rows := SliceRowSource([][]any{{1, "a"}, {2, "b"}, {3, "'"}, {4, nil}, {5, "e"}})
+	affected, err := mc.BulkInsert(context.Background(), "db.t", []string{"id", "name"}, rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if affected != 5 {
+		t.Errorf("got %d affected rows, want 5", affected)
+	}
+
+	var queries []string
+	for _, info := range tracer.done {
+		queries = append(queries, info.Query)
+	}
+	want := []string{
+		prefix + "(1,'a'),(2,'b')",
+		prefix + `(3,'\''),(4,NULL)`,
+		prefix + "(5,'e')",
+	}
+	if !reflect.DeepEqual(queries, want) {
+		t.Errorf("got queries %q, want %q", queries, want)
+	}
+}
+
+func TestBulkInsertQueryAttributes(t *testing.T) {
+	conn, mc := newRWMockConn(0)
+	mc.flags = clientQueryAttributes
+	ctx := WithQueryAttributes(context.Background(), QueryAttribute{"trace", "abc"})
+	prefix := "INSERT INTO `t` (`id`,`name`) VALUES "
+	// parameter counts, NULL-bitmap, bind flag, type, name and value
+	attrsLen := 1 + 1 + 1 + 1 + 2 + 6 + 4
+	// exactly two rows of 7 bytes per statement
+	mc.maxAllowedPacket = 1 + attrsLen + len(prefix) + 2*7 + 1
+	conn.queuedReplies = [][]byte{
+		makePackets(1, []byte{iOK, 0x02, 0x00, 0x02, 0x00, 0x00, 0x00}),
+		makePackets(1, []byte{iOK, 0x01, 0x00, 0x02, 0x00, 0x00, 0x00}),
+	}
+
+	rows := SliceRowSource([][]any{{1, "a"}, {2, "b"}, {3, "c"}})
+	affected, err := mc.BulkInsert(ctx, "t", []string{"id", "name"}, rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if affected != 3 {
+		t.Errorf("got %d affected rows, want 3", affected)
+	}
+
+	written := conn.written
+	for _, want := range []string{prefix + "(1,'a'),(2,'b')", prefix + "(3,'c')"} {
+		n := int(written[0]) | int(written[1])<<8 | int(written[2])<<16
+		payload := written[4 : 4+n]
+		if !bytes.Contains(payload[:1+attrsLen], []byte("trace")) || string(payload[1+attrsLen:]) != want {
+			t.Errorf("got packet %q, want the attributes and %q", payload, want)
+		}
+		written = written[4+n:]
+	}
+	if n := len(prefix + "(1,'a'),(2,'b')"); 1+attrsLen+n != mc.maxAllowedPacket {
+		t.Errorf("first statement of %d bytes does not fill the packet", n)
+	}
+	if mc.queryAttrs != nil {
+		t.Error("query attributes were not reset")
+	}
+}
+
+func TestBulkInsertModes(t *testing.T) {
+	tests := []struct {
+		opts []BulkInsertOption
+		want string
+	}{
+		{[]BulkInsertOption{InsertIgnore()}, "INSERT IGNORE INTO `t` (`id`,`n`) VALUES (1,2)"},
+		{[]BulkInsertOption{OnDuplicateKeyUpdate()}, "INSERT INTO `t` (`id`,`n`) VALUES (1,2) ON DUPLICATE KEY UPDATE `id`=VALUES(`id`),`n`=VALUES(`n`)"},
+		{[]BulkInsertOption{OnDuplicateKeyUpdate("n")}, "INSERT INTO `t` (`id`,`n`) VALUES (1,2) ON DUPLICATE KEY UPDATE `n`=VALUES(`n`)"},
+	}
+	for _, test := range tests {
+		tracer := new(recordingTracer)
+		conn, mc := newRWMockConn(0)
+		mc.cfg.tracer = tracer
+		conn.queuedReplies = [][]byte{makePackets(1, okPacket)}
+
+		rows := SliceRowSource([][]any{{1, 2}})
+		if _, err := mc.BulkInsert(context.Background(), "t", []string{"id", "n"}, rows, test.opts...); err != nil {
+			t.Fatal(err)
+		}
+		if len(tracer.done) != 1 || tracer.done[0].Query != test.want {
+			t.Errorf("got %+v, want query %q", tracer.done, test.want)
+		}
+	}
+}
+
+func TestBulkInsertErrors(t *testing.T) {
+	conn, mc := newRWMockConn(0)
+	mc.maxAllowedPacket = 64
+
+	ctx := context.Background()
+	if _, err := mc.BulkInsert(ctx, "t", nil, SliceRowSource(nil)); err != errBulkInsertNoColumns {
+		t.Errorf("expected errBulkInsertNoColumns, got %v", err)
+	}
+	if _, err := mc.BulkInsert(ctx, "t", []string{"a"}, SliceRowSource([][]any{{1, 2}})); err == nil {
+		t.Error("expected error for a row with too many values")
+	}
+	long := make([]byte, 64)
+	if _, err := mc.BulkInsert(ctx, "t", []string{"a"}, SliceRowSource([][]any{{long}})); !errors.Is(err, ErrPktTooLarge) {
+		t.Errorf("expected ErrPktTooLarge, got %v", err)
+	}
+	if len(conn.written) != 0 {
+		t.Errorf("statements were sent: %q", conn.written)
+	}
+}
@@ -0,0 +1,303 @@
+// Go MySQL Driver - A MySQL-Driver for Go's database/sql package
+//
+// Copyright 2024 The Go-MySQL-Driver Authors. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package mysql
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+)
+
+// Compressor implements a compression algorithm for the compressed
+// client/server protocol.
+//
+// Implementations must be safe for concurrent use by multiple connections.
+type Compressor interface {
+	// Compress appends the compressed form of src to dst and returns the
+	// extended buffer. A level of 0 selects the algorithm's default level.
+	Compress(dst, src []byte, level int) ([]byte, error)
+
+	// Decompress appends the decompressed form of src to dst and returns the
+	// extended buffer.
+	Decompress(dst, src []byte) ([]byte, error)
+}
+
+// Registry for compression algorithms
+var (
+	compressorsLock sync.RWMutex
+	compressors     = map[string]Compressor{
+		compressionZlib: &zlibCompressor{},
+	}
+)
+
+// RegisterCompressor registers a Compressor for the given compression
+// algorithm. Only "zlib" and "zstd" are understood by the server. zlib is
+// built in; zstd support requires registering an implementation, e.g. one
+// backed by github.com/klauspost/compress/zstd:
+//
+//	mysql.RegisterCompressor("zstd", myZstdCompressor{})
+//	db, err := sql.Open("mysql", "user@tcp(localhost:3306)/test?compress=true&compressionAlgorithm=zstd")
+func RegisterCompressor(algorithm string, c Compressor) error {
+	if algorithm != compressionZlib && algorithm != compressionZstd {
+		return fmt.Errorf("unsupported compression algorithm '%s'", algorithm)
+	}
+	if c == nil {
+		return fmt.Errorf("compressor for '%s' is nil", algorithm)
+	}
+
+	compressorsLock.Lock()
+	compressors[algorithm] = c
+	compressorsLock.Unlock()
+	return nil
+}
+
+// DeregisterCompressor removes the Compressor registered for the given
+// compression algorithm. Deregistering "zlib" restores the built-in
+// implementation.
+func DeregisterCompressor(algorithm string) {
+	compressorsLock.Lock()
+	if algorithm == compressionZlib {
+		compressors[algorithm] = &zlibCompressor{}
+	} else {
+		delete(compressors, algorithm)
+	}
+	compressorsLock.Unlock()
+}
+
+func getCompressor(algorithm string) Compressor {
+	compressorsLock.RLock()
+	c := compressors[algorithm]
+	compressorsLock.RUnlock()
+	return c
+}
+
+// zlibCompressor is the built-in Compressor for the "zlib" algorithm.
+// It pools zlib writers per compression level and readers to avoid
+// reallocating their internal state for every packet.
+type zlibCompressor struct {
+	writers sync.Map // compression level -> *sync.Pool of *zlib.Writer
+	readers sync.Pool
+}
+
+func (z *zlibCompressor) Compress(dst, src []byte, level int) ([]byte, error) {
+	if level == 0 {
+		level = zlib.DefaultCompression
+	}
+	p, _ := z.writers.LoadOrStore(level, &sync.Pool{})
+	pool := p.(*sync.Pool)
+
+	buf := bytes.NewBuffer(dst)
+	zw, _ := pool.Get().(*zlib.Writer)
+	if zw == nil {
+		var err error
+		if zw, err = zlib.NewWriterLevel(buf, level); err != nil {
+			return dst, err
+		}
+	} else {
+		zw.Reset(buf)
+	}
+	defer pool.Put(zw)
+
+	if _, err := zw.Write(src); err != nil {
+		return dst, err
+	}
+	if err := zw.Close(); err != nil {
+		return dst, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (z *zlibCompressor) Decompress(dst, src []byte) ([]byte, error) {
+	var err error
+	br := bytes.NewReader(src)
+	zr, _ := z.readers.Get().(io.ReadCloser)
+	if zr == nil {
+		if zr, err = zlib.NewReader(br); err != nil {
+			return dst, err
+		}
+	} else if err = zr.(zlib.Resetter).Reset(br, nil); err != nil {
+		return dst, err
+	}
+	defer z.readers.Put(zr)
+
+	buf := bytes.NewBuffer(dst)
+	if _, err = buf.ReadFrom(zr); err != nil {
+		return dst, err
+	}
+	return buf.Bytes(), zr.Close()
+}
+
+// compIO implements the compressed protocol on top of a net.Conn.
+// Each Write is sent as one or more compressed packets; Read returns the
+// decompressed payload of the received compressed packets.
+//
+// Compressed packet layout:
+//
+//	compressed payload length   [3 bytes]
+//	compressed sequence number  [1 byte]
+//	uncompressed payload length [3 bytes] (0: payload is not compressed)
+//	payload                     [n bytes]
+//
+// https://dev.mysql.com/doc/dev/mysql-server/latest/page_protocol_basic_compression.html
+type compIO struct {
+	net.Conn
+	mc         *mysqlConn
+	compressor Compressor
+	level      int
+	threshold  int
+	br         *bufio.Reader
+	rbuf       []byte // decompressed data not yet returned by Read
+	zbuf       []byte // scratch buffer for compressed payloads
+	dbuf       []byte // scratch buffer for decompressed payloads
+	wbuf       []byte // scratch buffer for outgoing compressed packets
+}
+
+const compressedHeaderSize = 7
+
+func newCompIO(mc *mysqlConn, conn net.Conn, compressor Compressor) *compIO {
+	threshold := mc.cfg.compressionThreshold
+	if threshold <= 0 {
+		threshold = defaultCompressionThreshold
+	}
+	return &compIO{
+		Conn:       conn,
+		mc:         mc,
+		compressor: compressor,
+		level:      mc.cfg.compressionLevel,
+		threshold:  threshold,
+		br:         bufio.NewReaderSize(conn, defaultBufSize),
+	}
+}
+
+// busy returns true if decompressed data or compressed packets are still
+// waiting to be read.
+func (c *compIO) busy() bool {
+	return len(c.rbuf) > 0 || c.br.Buffered() > 0
+}
+
+func (c *compIO) Read(p []byte) (int, error) {
+	for len(c.rbuf) == 0 {
+		if err := c.readCompressedPacket(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, c.rbuf)
+	c.rbuf = c.rbuf[n:]
+	return n, nil
+}
+
+func (c *compIO) readCompressedPacket() error {
+	var header [compressedHeaderSize]byte
+	if _, err := io.ReadFull(c.br, header[:]); err != nil {
+		return err
+	}
+
+	comprLen := int(uint32(header[0]) | uint32(header[1])<<8 | uint32(header[2])<<16)
+	uncomprLen := int(uint32(header[4]) | uint32(header[5])<<8 | uint32(header[6])<<16)
+
+	// The sequence of the compressed packets is checked by neither the
+	// server nor libmysqlclient, as the server may send an error packet
+	// before it has read all packets of a command. Packets sent after this
+	// one continue its sequence.
+	c.mc.compressSequence = header[3] + 1
+	c.mc.sequence = c.mc.compressSequence
+
+	if cap(c.zbuf) < comprLen {
+		c.zbuf = make([]byte, comprLen)
+	}
+	payload := c.zbuf[:comprLen]
+	if _, err := io.ReadFull(c.br, payload); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return err
+	}
+	if m := c.mc.metrics; m != nil {
+		m.PacketRead(compressedHeaderSize + comprLen)
+	}
+
+	// the payload was sent uncompressed
+	if uncomprLen == 0 {
+		c.rbuf = payload
+		return nil
+	}
+
+	data, err := c.compressor.Decompress(c.dbuf[:0], payload)
+	if err != nil {
+		return err
+	}
+	if len(data) != uncomprLen {
+		return fmt.Errorf("invalid compressed packet: uncompressed length in header is %d, actual %d", uncomprLen, len(data))
+	}
+	if cap(data) <= maxCachedBufSize {
+		c.dbuf = data
+	}
+	c.rbuf = data
+	return nil
+}
+
+// Write sends data as compressed packets. Payloads smaller than the
+// compression threshold, and payloads which do not shrink, are sent
+// uncompressed.
+func (c *compIO) Write(data []byte) (int, error) {
+	written := 0
+	for len(data) > 0 {
+		payload := data[:min(len(data), maxPacketSize)]
+
+		var header [compressedHeaderSize]byte
+		buf := append(c.wbuf[:0], header[:]...)
+		uncomprLen := 0
+		if len(payload) >= c.threshold {
+			var err error
+			buf, err = c.compressor.Compress(buf, payload, c.level)
+			if err != nil {
+				return written, err
+			}
+			uncomprLen = len(payload)
+		}
+		if uncomprLen == 0 || len(buf)-compressedHeaderSize >= len(payload) {
+			buf = append(buf[:compressedHeaderSize], payload...)
+			uncomprLen = 0
+		}
+		if cap(buf) <= maxCachedBufSize {
+			c.wbuf = buf
+		}
+
+		comprLen := len(buf) - compressedHeaderSize
+		buf[0] = byte(comprLen)
+		buf[1] = byte(comprLen >> 8)
+		buf[2] = byte(comprLen >> 16)
+		buf[3] = c.mc.compressSequence
+		buf[4] = byte(uncomprLen)
+		buf[5] = byte(uncomprLen >> 8)
+		buf[6] = byte(uncomprLen >> 16)
+
+		n, err := c.Conn.Write(buf)
+		if err != nil {
+			// n counts compressed bytes. Any non-zero value tells writePacket
+			// that the command has (partially) reached the network.
+			return written + min(n, len(payload)), err
+		}
+		if n != len(buf) {
+			return written, io.ErrShortWrite
+		}
+
+		if m := c.mc.metrics; m != nil {
+			m.PacketWritten(len(buf))
+		}
+		c.mc.compressSequence++
+		written += len(payload)
+		data = data[len(payload):]
+	}
+	return written, nil
+}
@@ -0,0 +1,183 @@
+// Go MySQL Driver - A MySQL-Driver for Go's database/sql package
+//
+// Copyright 2024 The Go-MySQL-Driver Authors. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package mysql
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+)
+
+func newCompressedRWMockConn(sequence uint8) (*mockConn, *mysqlConn) {
+	conn, mc := newRWMockConn(sequence)
+	mc.compressor = getCompressor(compressionZlib)
+	mc.startCompression()
+	return conn, mc
+}
+
+func makeCompressiblePacket(size int) []byte {
+	data := make([]byte, 4+size)
+	for i := 4; i < len(data); i++ {
+		data[i] = byte('a' + i%7)
+	}
+	return data
+}
+
+func makeRandomPacket(size int) []byte {
+	data := make([]byte, 4+size)
+	rand.Read(data[4:])
+	return data
+}
+
+// roundtrip writes the packet through one compressed connection and reads
+// it back through another one.
+func roundtrip(t *testing.T, packet []byte) ([]byte, []byte) {
+	t.Helper()
+	want := append([]byte(nil), packet[4:]...)
+
+	wconn, wmc := newCompressedRWMockConn(0)
+	if err := wmc.writePacket(packet); err != nil {
+		t.Fatal(err)
+	}
+
+	rconn, rmc := newCompressedRWMockConn(0)
+	rconn.data = wconn.written
+	got, err := rmc.readPacket()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatalf("roundtrip mismatch: got %d bytes, want %d bytes", len(got), len(want))
+	}
+	return wconn.written, got
+}
+
+func TestCompressRoundtrip(t *testing.T) {
+	for _, size := range []int{1, 49, 50, 1000, 64 * 1024, maxPacketSize + 100} {
+		roundtrip(t, makeCompressiblePacket(size))
+		roundtrip(t, makeRandomPacket(size))
+	}
+}
+
+func TestCompressSmallPayloadUncompressed(t *testing.T) {
+	written, _ := roundtrip(t, makeCompressiblePacket(10))
+
+	// compressed length [3 bytes]: header + payload of the inner packet
+	if n := int(written[0]) | int(written[1])<<8 | int(written[2])<<16; n != 4+10 {
+		t.Errorf("expected compressed length %d, got %d", 4+10, n)
+	}
+	// uncompressed length [3 bytes]: 0 as the payload is sent as is
+	if !bytes.Equal(written[4:7], []byte{0, 0, 0}) {
+		t.Errorf("expected uncompressed payload, got header %v", written[:7])
+	}
+}
+
+func TestCompressLargePayloadCompressed(t *testing.T) {
+	written, _ := roundtrip(t, makeCompressiblePacket(1000))
+
+	if n := int(written[4]) | int(written[5])<<8 | int(written[6])<<16; n != 4+1000 {
+		t.Errorf("expected uncompressed length %d, got %d", 4+1000, n)
+	}
+	if len(written) >= 7+4+1000 {
+		t.Errorf("payload was not compressed: %d bytes written", len(written))
+	}
+}
+
+func TestCompressIncompressiblePayloadSentAsIs(t *testing.T) {
+	written, _ := roundtrip(t, makeRandomPacket(1000))
+
+	if !bytes.Equal(written[4:7], []byte{0, 0, 0}) {
+		t.Errorf("expected uncompressed payload, got header %v", written[:7])
+	}
+}
+
+func TestCompressSequence(t *testing.T) {
+	conn, mc := newCompressedRWMockConn(0)
+
+	if err := mc.writeCommandPacketStr(comQuery, "SELECT 1"); err != nil {
+		t.Fatal(err)
+	}
+	if conn.written[3] != 0 {
+		t.Errorf("expected compressed sequence 0, got %d", conn.written[3])
+	}
+
+	// The server replies with compressed sequence 1; the next packet sent
+	// in the same command continues that sequence.
+	conn.data = []byte{0x05, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x01, 0x01}
+	if _, err := mc.readPacket(); err != nil {
+		t.Fatal(err)
+	}
+	if mc.compressSequence != 2 {
+		t.Errorf("expected compressed sequence 2, got %d", mc.compressSequence)
+	}
+
+	conn.written = nil
+	if err := mc.writePacket(make([]byte, 4+1)); err != nil {
+		t.Fatal(err)
+	}
+	if conn.written[3] != 2 {
+		t.Errorf("expected compressed sequence 2, got %d", conn.written[3])
+	}
+
+	// a new command resets the sequence
+	conn.written = nil
+	if err := mc.writeCommandPacket(comPing); err != nil {
+		t.Fatal(err)
+	}
+	if conn.written[3] != 0 {
+		t.Errorf("expected compressed sequence 0, got %d", conn.written[3])
+	}
+}
+
+func TestCompressBusy(t *testing.T) {
+	conn, mc := newCompressedRWMockConn(0)
+
+	// two compressed packets, which are read from the connection at once
+	conn.data = []byte{
+		0x05, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x01, 0x01,
+		0x05, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x02, 0x02,
+	}
+	if _, err := mc.readPacket(); err != nil {
+		t.Fatal(err)
+	}
+	if !mc.busy() {
+		t.Error("connection is not busy with a compressed packet buffered")
+	}
+	if _, err := mc.readPacket(); err != nil {
+		t.Fatal(err)
+	}
+	if mc.busy() {
+		t.Error("connection is busy after reading all packets")
+	}
+}
+
+func TestRegisterCompressor(t *testing.T) {
+	if err := RegisterCompressor("lz4", &zlibCompressor{}); err == nil {
+		t.Error("expected error for unsupported algorithm")
+	}
+
+	if getCompressor(compressionZstd) != nil {
+		t.Fatal("zstd must not be registered by default")
+	}
+	if err := RegisterCompressor(compressionZstd, &zlibCompressor{}); err != nil {
+		t.Fatal(err)
+	}
+	if getCompressor(compressionZstd) == nil {
+		t.Error("zstd compressor was not registered")
+	}
+	DeregisterCompressor(compressionZstd)
+	if getCompressor(compressionZstd) != nil {
+		t.Error("zstd compressor was not deregistered")
+	}
+
+	DeregisterCompressor(compressionZlib)
+	if getCompressor(compressionZlib) == nil {
+		t.Error("zlib compressor must always be available")
+	}
+}
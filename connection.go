@@ -13,16 +13,102 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"encoding/json"
-	"fmt"
+	"errors"
 	"io"
+	"log/slog"
 	"net"
-	"runtime"
 	"strconv"
 	"strings"
 	"sync/atomic"
 	"time"
 )
 
+// Conn exposes functionality of a connection which is specific to MySQL.
+//
+// It can be accessed through sql.Conn.Raw():
+//
+//	conn, _ := db.Conn(ctx)
+//	conn.Raw(func(conn any) error {
+//		state := conn.(mysql.Conn).SessionState()
+//		log.Print(state.GTIDs)
+//		return nil
+//	})
+type Conn interface {
+	driver.Conn
+
+	// SessionState returns the session state last reported by the server.
+	SessionState() SessionState
+
+	// ChangeUser authenticates the connection as another user with
+	// COM_CHANGE_USER and selects the database dbName, which may be empty.
+	// The session is reset as if the connection was new: session variables,
+	// temporary tables and prepared statements are discarded, and the
+	// charset, collation and system variables of the DSN are applied again.
+	//
+	// The connection keeps the new user when it is returned to the pool.
+	// If the authentication fails, the connection is closed. The user can
+	// not be changed during a transaction, including one started with
+	// START TRANSACTION, or an XA transaction.
+	ChangeUser(ctx context.Context, user, password, dbName string) error
+
+	// Savepoint sets a savepoint with the given name in the active
+	// transaction. An existing savepoint with the same name is replaced.
+	Savepoint(ctx context.Context, name string) error
+	// RollbackTo rolls the active transaction back to the savepoint name.
+	// The savepoint is kept, later savepoints are removed.
+	RollbackTo(ctx context.Context, name string) error
+	// Release removes the savepoint name and all later savepoints without
+	// rolling back.
+	Release(ctx context.Context, name string) error
+
+	// XAStart starts the XA transaction branch xid. Until it is committed or
+	// rolled back, the connection is not returned to the pool; if it is
+	// released earlier, it is closed. Closing the connection rolls back a
+	// branch which has not been prepared. Errors of the XA statements are
+	// returned as *XAError.
+	XAStart(ctx context.Context, xid Xid) error
+	// XAEnd ends the statements of the branch xid.
+	XAEnd(ctx context.Context, xid Xid) error
+	// XAPrepare prepares the branch xid for the commit. A prepared branch
+	// survives the connection and can be committed or rolled back from
+	// another connection.
+	XAPrepare(ctx context.Context, xid Xid) error
+	// XACommit commits the branch xid. A branch which has been ended but not
+	// prepared on this connection is committed in one phase.
+	XACommit(ctx context.Context, xid Xid) error
+	// XARollback rolls back the branch xid.
+	XARollback(ctx context.Context, xid Xid) error
+	// XARecover returns the prepared branches of the server.
+	XARecover(ctx context.Context) ([]Xid, error)
+
+	// ExecBatch sends the statements of b without waiting for their results
+	// and returns their results in order. A round trip is needed for about
+	// every 16 KiB of statements. The arguments are interpolated into the
+	// statements on the client, regardless of interpolateParams. All
+	// statements are executed even if some of them fail; their server errors
+	// are returned in the results. The rows of statements returning rows are
+	// discarded. The error is only set if no statement was sent or if the
+	// connection broke.
+	ExecBatch(ctx context.Context, b *Batch) ([]BatchResult, error)
+	// ExecPipelined prepares query and executes it once for each of the
+	// argument lists. The execute packets are sent back to back and their
+	// results are read afterwards, in order, so that the executions cost a
+	// round trip for about every 16 KiB of execute packets. A failed
+	// execution does not stop the others; its server error is returned in
+	// its result. The error is only set if no execution was sent or if the
+	// connection broke.
+	ExecPipelined(ctx context.Context, query string, args [][]any) ([]BatchResult, error)
+
+	// BulkInsert inserts the rows of rows into the columns of table with
+	// multi-row INSERT statements, each as large as max_allowed_packet
+	// allows. table may be qualified with the database, e.g. "db.t". It
+	// returns the total number of affected rows, which counts updated rows
+	// twice with OnDuplicateKeyUpdate. The statements are not atomic unless
+	// they run within a transaction; after an error, the number of rows
+	// affected by the statements which succeeded is returned.
+	BulkInsert(ctx context.Context, table string, columns []string, rows RowSource, opts ...BulkInsertOption) (int64, error)
+}
+
 type mysqlConn struct {
 	buf              buffer
 	netConn          net.Conn
@@ -36,7 +122,25 @@ type mysqlConn struct {
 	flags            clientFlag
 	status           statusFlag
 	sequence         uint8
+	compressSequence uint8
 	parseTime        bool
+	compress         bool                                 // set once the compressed protocol is in use
+	compressor       Compressor                           // negotiated during the handshake
+	session          SessionState                         // updated by handleOkPacket()
+	scramble         []byte                               // auth data of the handshake, reused by COM_CHANGE_USER
+	authPlugin       string                               // auth plugin of the handshake
+	sessionResets    uint32                               // incremented when a reset deallocates the prepared statements
+	queryAttrs       []QueryAttribute                     // attributes of the current statement, from its context
+	authExchange     AuthExchange                         // exchange of a registered auth plugin during authentication
+	connectionID     uint32                               // connection id of the handshake
+	connectTrace     func(ConnectPhase, time.Time, error) // reports connect phases to the tracer while connecting
+	metrics          MetricsCollector                     // cfg.metrics, copied for the packet functions
+	warningCount     uint16                               // warning count of the last OK or EOF packet
+	sqlText          string                               // statement sent by the current command, for MySQLError.Query
+	inTx             bool                                 // a mysqlTx is active, statements are not retried
+	nestedTx         int                                  // number of nested transactions emulated with savepoints
+	xaState          xaState                              // state of the XA transaction
+	bytesSent        int                                  // bytes written by writePacket, to bound pipelines
 
 	// for context support (Go 1.8+)
 	watching bool
@@ -47,19 +151,24 @@ type mysqlConn struct {
 	closed   atomic.Bool // set when conn is closed, before closech is closed
 }
 
-// Helper function to call per-connection logger.
-func (mc *mysqlConn) log(v ...any) {
-	_, filename, lineno, ok := runtime.Caller(1)
-	if ok {
-		pos := strings.LastIndexByte(filename, '/')
-		if pos != -1 {
-			filename = filename[pos+1:]
-		}
-		prefix := fmt.Sprintf("%s:%d ", filename, lineno)
-		v = append([]any{prefix}, v...)
-	}
+// startCompression switches the connection to the compressed protocol
+// negotiated during the handshake. It must be called after authentication.
+func (mc *mysqlConn) startCompression() {
+	cio := newCompIO(mc, mc.netConn, mc.compressor)
+	mc.netConn = cio
+	mc.buf.nc = cio
+	mc.compress = true
+}
 
-	mc.cfg.Logger.Print(v...)
+// busy returns true if unread data is buffered for the connection, or if
+// an XA transaction has not been committed or rolled back. Such connections
+// are not returned to the pool.
+func (mc *mysqlConn) busy() bool {
+	if mc.buf.busy() || mc.xaState != xaNone {
+		return true
+	}
+	cio, ok := mc.netConn.(*compIO)
+	return ok && cio.busy()
 }
 
 // Handles parameters set in DSN after the connection is established
@@ -86,20 +195,115 @@ func (mc *mysqlConn) handleParams() (err error) {
 	return
 }
 
+// initSession sets the charset, the collation and the system variables
+// given in the DSN.
+func (mc *mysqlConn) initSession() (err error) {
+	// Charset: character_set_connection, character_set_client, character_set_results
+	if len(mc.cfg.charsets) > 0 {
+		for _, cs := range mc.cfg.charsets {
+			// ignore errors here - a charset may not exist
+			if mc.cfg.Collation != "" {
+				err = mc.exec("SET NAMES " + cs + " COLLATE " + mc.cfg.Collation)
+			} else {
+				err = mc.exec("SET NAMES " + cs)
+			}
+			if err == nil {
+				break
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	// Handle DSN Params
+	return mc.handleParams()
+}
+
+// resetSession resets the session state on the server and initializes the
+// session again. Servers without COM_RESET_CONNECTION get COM_CHANGE_USER
+// with the current user instead.
+func (mc *mysqlConn) resetSession() error {
+	handleOk := mc.clearResult()
+	if err := mc.writeCommandPacket(comResetConnection); err != nil {
+		return err
+	}
+	err := handleOk.readResultOK()
+	var mysqlErr *MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == 1047 { // ER_UNKNOWN_COM_ERROR
+		err = mc.changeUser()
+	}
+	if err != nil {
+		return err
+	}
+	mc.sessionResets++
+
+	mc.session = SessionState{Schema: mc.cfg.DBName}
+	return mc.initSession()
+}
+
+// changeUser authenticates the connection again as the user of mc.cfg with
+// COM_CHANGE_USER. This resets the session state like COM_RESET_CONNECTION.
+func (mc *mysqlConn) changeUser() error {
+	plugin := mc.authPlugin
+	if plugin == "" {
+		plugin = defaultAuthPlugin
+	}
+	authResp, err := mc.auth(mc.scramble, plugin)
+	if err != nil {
+		return err
+	}
+	mc.clearResult()
+	if err = mc.writeChangeUserPacket(authResp, plugin); err != nil {
+		return err
+	}
+	return mc.handleAuthResult(mc.scramble, plugin)
+}
+
+func (mc *mysqlConn) ChangeUser(ctx context.Context, user, password, dbName string) error {
+	if mc.closed.Load() {
+		return driver.ErrBadConn
+	}
+	// COM_CHANGE_USER rolls the transaction back.
+	if mc.inTx || mc.status&statusInTrans != 0 || mc.xaState != xaNone {
+		return errChangeUserInTx
+	}
+	if err := mc.watchCancel(ctx); err != nil {
+		return err
+	}
+	defer mc.finish()
+
+	cfg := mc.cfg.Clone()
+	cfg.User = user
+	cfg.Passwd = password
+	cfg.DBName = dbName
+	mc.cfg = cfg
+
+	if err := mc.changeUser(); err != nil {
+		// The session of the previous user is gone, too.
+		mc.cleanup()
+		return err
+	}
+	mc.sessionResets++
+	mc.session = SessionState{Schema: dbName}
+	return mc.initSession()
+}
+
 // markBadConn replaces errBadConnNoWrite with driver.ErrBadConn.
 // This function is used to return driver.ErrBadConn only when safe to retry.
 func (mc *mysqlConn) markBadConn(err error) error {
 	if err == errBadConnNoWrite {
+		mc.collectBadConn()
 		return driver.ErrBadConn
 	}
 	return err
 }
 
 func (mc *mysqlConn) Begin() (driver.Tx, error) {
-	return mc.begin(false)
+	return mc.begin(context.Background(), false)
 }
 
-func (mc *mysqlConn) begin(readOnly bool) (driver.Tx, error) {
+func (mc *mysqlConn) begin(ctx context.Context, readOnly bool) (driver.Tx, error) {
 	if mc.closed.Load() {
 		return nil, driver.ErrBadConn
 	}
@@ -109,9 +313,12 @@ func (mc *mysqlConn) begin(readOnly bool) (driver.Tx, error) {
 	} else {
 		q = "START TRANSACTION"
 	}
+	done := mc.traceQuery(ctx, q, 0)
 	err := mc.exec(q)
+	done(nil, err)
 	if err == nil {
-		return &mysqlTx{mc}, err
+		mc.inTx = true
+		return &mysqlTx{mc: mc, ctx: ctx}, err
 	}
 	return nil, mc.markBadConn(err)
 }
@@ -147,7 +354,7 @@ func (mc *mysqlConn) cleanup() {
 		return
 	}
 	if err := conn.Close(); err != nil {
-		mc.log(err)
+		mc.logEvent(slog.LevelWarn, "closing connection failed", err)
 	}
 	// This function can be called from multiple goroutines.
 	// So we can not mc.clearResult() here.
@@ -172,25 +379,27 @@ func (mc *mysqlConn) Prepare(query string) (driver.Stmt, error) {
 	err := mc.writeCommandPacketStr(comStmtPrepare, query)
 	if err != nil {
 		// STMT_PREPARE is safe to retry.  So we can return ErrBadConn here.
-		mc.log(err)
+		mc.logEvent(slog.LevelError, "sending prepare command failed", err)
 		return nil, driver.ErrBadConn
 	}
 
 	stmt := &mysqlStmt{
-		mc: mc,
+		mc:     mc,
+		sql:    query,
+		resets: mc.sessionResets,
 	}
 
 	// Read Result
 	columnCount, err := stmt.readPrepareResultPacket()
 	if err == nil {
 		if stmt.paramCount > 0 {
-			if err = mc.readUntilEOF(); err != nil {
+			if err = mc.skipColumns(stmt.paramCount); err != nil {
 				return nil, err
 			}
 		}
 
 		if columnCount > 0 {
-			err = mc.readUntilEOF()
+			err = mc.skipColumns(int(columnCount))
 		}
 	}
 
@@ -226,76 +435,86 @@ func (mc *mysqlConn) interpolateParams(query string, args []driver.Value) (strin
 		arg := args[argPos]
 		argPos++
 
-		if arg == nil {
-			buf = append(buf, "NULL"...)
-			continue
-		}
-
-		switch v := arg.(type) {
-		case int64:
-			buf = strconv.AppendInt(buf, v, 10)
-		case uint64:
-			// Handle uint64 explicitly because our custom ConvertValue emits unsigned values
-			buf = strconv.AppendUint(buf, v, 10)
-		case float64:
-			buf = strconv.AppendFloat(buf, v, 'g', -1, 64)
-		case bool:
-			if v {
-				buf = append(buf, '1')
-			} else {
-				buf = append(buf, '0')
-			}
-		case time.Time:
-			if v.IsZero() {
-				buf = append(buf, "'0000-00-00'"...)
-			} else {
-				buf = append(buf, '\'')
-				buf, err = appendDateTime(buf, v.In(mc.cfg.Loc), mc.cfg.timeTruncate)
-				if err != nil {
-					return "", err
-				}
-				buf = append(buf, '\'')
+		if buf, err = mc.appendValue(buf, arg); err != nil {
+			return "", err
+		}
+
+		if len(buf)+4 > mc.maxAllowedPacket {
+			return "", driver.ErrSkip
+		}
+	}
+	if argPos != len(args) {
+		return "", driver.ErrSkip
+	}
+	return string(buf), nil
+}
+
+// appendValue appends arg as an SQL literal to buf, escaped according to
+// the NO_BACKSLASH_ESCAPES mode of the session. driver.ErrSkip is returned
+// for unsupported types.
+func (mc *mysqlConn) appendValue(buf []byte, arg driver.Value) ([]byte, error) {
+	if arg == nil {
+		return append(buf, "NULL"...), nil
+	}
+
+	switch v := arg.(type) {
+	case int64:
+		buf = strconv.AppendInt(buf, v, 10)
+	case uint64:
+		// Handle uint64 explicitly because our custom ConvertValue emits unsigned values
+		buf = strconv.AppendUint(buf, v, 10)
+	case float64:
+		buf = strconv.AppendFloat(buf, v, 'g', -1, 64)
+	case bool:
+		if v {
+			buf = append(buf, '1')
+		} else {
+			buf = append(buf, '0')
+		}
+	case time.Time:
+		if v.IsZero() {
+			buf = append(buf, "'0000-00-00'"...)
+		} else {
+			buf = append(buf, '\'')
+			var err error
+			buf, err = appendDateTime(buf, v.In(mc.cfg.Loc), mc.cfg.timeTruncate)
+			if err != nil {
+				return nil, err
 			}
-		case json.RawMessage:
 			buf = append(buf, '\'')
+		}
+	case json.RawMessage:
+		buf = append(buf, '\'')
+		if mc.status&statusNoBackslashEscapes == 0 {
+			buf = escapeBytesBackslash(buf, v)
+		} else {
+			buf = escapeBytesQuotes(buf, v)
+		}
+		buf = append(buf, '\'')
+	case []byte:
+		if v == nil {
+			buf = append(buf, "NULL"...)
+		} else {
+			buf = append(buf, "_binary'"...)
 			if mc.status&statusNoBackslashEscapes == 0 {
 				buf = escapeBytesBackslash(buf, v)
 			} else {
 				buf = escapeBytesQuotes(buf, v)
 			}
 			buf = append(buf, '\'')
-		case []byte:
-			if v == nil {
-				buf = append(buf, "NULL"...)
-			} else {
-				buf = append(buf, "_binary'"...)
-				if mc.status&statusNoBackslashEscapes == 0 {
-					buf = escapeBytesBackslash(buf, v)
-				} else {
-					buf = escapeBytesQuotes(buf, v)
-				}
-				buf = append(buf, '\'')
-			}
-		case string:
-			buf = append(buf, '\'')
-			if mc.status&statusNoBackslashEscapes == 0 {
-				buf = escapeStringBackslash(buf, v)
-			} else {
-				buf = escapeStringQuotes(buf, v)
-			}
-			buf = append(buf, '\'')
-		default:
-			return "", driver.ErrSkip
 		}
-
-		if len(buf)+4 > mc.maxAllowedPacket {
-			return "", driver.ErrSkip
+	case string:
+		buf = append(buf, '\'')
+		if mc.status&statusNoBackslashEscapes == 0 {
+			buf = escapeStringBackslash(buf, v)
+		} else {
+			buf = escapeStringQuotes(buf, v)
 		}
+		buf = append(buf, '\'')
+	default:
+		return nil, driver.ErrSkip
 	}
-	if argPos != len(args) {
-		return "", driver.ErrSkip
-	}
-	return string(buf), nil
+	return buf, nil
 }
 
 func (mc *mysqlConn) Exec(query string, args []driver.Value) (driver.Result, error) {
@@ -316,8 +535,7 @@ func (mc *mysqlConn) Exec(query string, args []driver.Value) (driver.Result, err
 
 	err := mc.exec(query)
 	if err == nil {
-		copied := mc.result
-		return &copied, err
+		return mc.execResult()
 	}
 	return nil, mc.markBadConn(err)
 }
@@ -326,7 +544,7 @@ func (mc *mysqlConn) Exec(query string, args []driver.Value) (driver.Result, err
 func (mc *mysqlConn) exec(query string) error {
 	handleOk := mc.clearResult()
 	// Send command
-	if err := mc.writeCommandPacketStr(comQuery, query); err != nil {
+	if err := mc.writeQueryPacket(query); err != nil {
 		return mc.markBadConn(err)
 	}
 
@@ -338,7 +556,7 @@ func (mc *mysqlConn) exec(query string) error {
 
 	if resLen > 0 {
 		// columns
-		if err := mc.readUntilEOF(); err != nil {
+		if err := mc.skipColumns(resLen); err != nil {
 			return err
 		}
 
@@ -351,6 +569,105 @@ func (mc *mysqlConn) exec(query string) error {
 	return handleOk.discardResults()
 }
 
+// execPipeline sends the queries without waiting for their results and then
+// reads their results in order, so that they cost a single round trip. The
+// results of all queries are read even if some of them fail; the server
+// error of each query is returned in errs. For queries returning rows,
+// values holds the first column of the first row. err is only set if the
+// connection is broken.
+func (mc *mysqlConn) execPipeline(queries []string) (values [][]byte, errs []error, err error) {
+	values = make([][]byte, len(queries))
+	errs = make([]error, len(queries))
+	err = mc.pipeline(len(queries), func(i int) error {
+		return mc.writeQueryPacket(queries[i])
+	}, func(i int) error {
+		mc.sqlText = queries[i]
+		values[i], errs[i] = mc.readPipelineResult()
+		var mysqlErr *MySQLError
+		if errs[i] != nil && !errors.As(errs[i], &mysqlErr) {
+			return errs[i]
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return values, errs, nil
+}
+
+// pipelineWindow is the number of bytes of pipelined commands which are sent
+// before their results are read. The server does not read further commands
+// while it can not send its replies, so sending a pipeline larger than the
+// socket buffers before reading would block both sides.
+const pipelineWindow = 16 * 1024
+
+// pipeline sends n commands with write and reads their results with read,
+// in order. Once pipelineWindow bytes of commands have been sent, the
+// results of the commands sent so far are read before more commands are
+// sent. read returns an error only if the connection is broken, which ends
+// the pipeline.
+func (mc *mysqlConn) pipeline(n int, write func(i int) error, read func(i int) error) error {
+	// The replies continue the sequence of their command.
+	sequences := make([][2]uint8, n)
+	for next := 0; next < n; {
+		sent, start := next, mc.bytesSent
+		for sent < n && (sent == next || mc.bytesSent-start < pipelineWindow) {
+			if err := write(sent); err != nil {
+				if sent == 0 {
+					return mc.markBadConn(err)
+				}
+				// The results of the commands already sent can not be skipped.
+				mc.cleanup()
+				return ErrInvalidConn
+			}
+			sequences[sent] = [2]uint8{mc.sequence, mc.compressSequence}
+			sent++
+		}
+		for ; next < sent; next++ {
+			mc.sequence, mc.compressSequence = sequences[next][0], sequences[next][1]
+			if err := read(next); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// readPipelineResult reads the result of a query sent by execPipeline.
+func (mc *mysqlConn) readPipelineResult() ([]byte, error) {
+	handleOk := mc.clearResult()
+	resLen, err := handleOk.readResultSetHeaderPacket()
+	if err != nil {
+		return nil, err
+	}
+
+	var value []byte
+	if resLen > 0 {
+		if err := mc.skipColumns(resLen); err != nil {
+			return nil, err
+		}
+		data, err := mc.readPacket()
+		if err != nil {
+			return nil, err
+		}
+		if mc.isEOFPacket(data) {
+			err = mc.handleEOFPacket(data)
+		} else if data[0] == iERR {
+			return nil, mc.handleErrorPacket(data)
+		} else {
+			value, _, _, err = readLengthEncodedString(data)
+			value = append([]byte(nil), value...)
+			if err == nil {
+				err = mc.readUntilEOF()
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return value, handleOk.discardResults()
+}
+
 func (mc *mysqlConn) Query(query string, args []driver.Value) (driver.Rows, error) {
 	return mc.query(query, args)
 }
@@ -373,7 +690,7 @@ func (mc *mysqlConn) query(query string, args []driver.Value) (*textRows, error)
 		query = prepared
 	}
 	// Send command
-	err := mc.writeCommandPacketStr(comQuery, query)
+	err := mc.writeQueryPacket(query)
 	if err != nil {
 		return nil, mc.markBadConn(err)
 	}
@@ -390,6 +707,9 @@ func (mc *mysqlConn) query(query string, args []driver.Value) (*textRows, error)
 
 	if resLen == 0 {
 		rows.rs.done = true
+		if err := rows.readWarnings(mc); err != nil {
+			return nil, err
+		}
 
 		switch err := rows.NextResultSet(); err {
 		case nil, io.EOF:
@@ -409,7 +729,7 @@ func (mc *mysqlConn) query(query string, args []driver.Value) (*textRows, error)
 func (mc *mysqlConn) getSystemVar(name string) ([]byte, error) {
 	// Send command
 	handleOk := mc.clearResult()
-	if err := mc.writeCommandPacketStr(comQuery, "SELECT @@"+name); err != nil {
+	if err := mc.writeQueryPacket("SELECT @@" + name); err != nil {
 		return nil, err
 	}
 
@@ -422,7 +742,7 @@ func (mc *mysqlConn) getSystemVar(name string) ([]byte, error) {
 
 		if resLen > 0 {
 			// Columns
-			if err := mc.readUntilEOF(); err != nil {
+			if err := mc.skipColumns(resLen); err != nil {
 				return nil, err
 			}
 		}
@@ -483,18 +803,17 @@ func (mc *mysqlConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver
 	}
 	defer mc.finish()
 
-	if sql.IsolationLevel(opts.Isolation) != sql.LevelDefault {
-		level, err := mapIsolationLevel(opts.Isolation)
-		if err != nil {
-			return nil, err
-		}
-		err = mc.exec("SET TRANSACTION ISOLATION LEVEL " + level)
-		if err != nil {
-			return nil, err
+	ext, hasExt := txOptions(ctx)
+	if mc.inTx {
+		if hasExt {
+			return nil, errNestedTxOptions
 		}
+		return mc.beginNested(ctx, opts)
 	}
-
-	return mc.begin(opts.ReadOnly)
+	if hasExt || sql.IsolationLevel(opts.Isolation) != sql.LevelDefault {
+		return mc.beginWithOptions(ctx, opts, ext)
+	}
+	return mc.begin(ctx, opts.ReadOnly)
 }
 
 func (mc *mysqlConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
@@ -502,14 +821,26 @@ func (mc *mysqlConn) QueryContext(ctx context.Context, query string, args []driv
 	if err != nil {
 		return nil, err
 	}
-
-	if err := mc.watchCancel(ctx); err != nil {
-		return nil, err
+	if len(dargs) != 0 && !mc.cfg.InterpolateParams {
+		return nil, driver.ErrSkip // not traced, database/sql prepares the statement instead
 	}
 
-	rows, err := mc.query(query, dargs)
+	mc.queryAttrs = queryAttributes(ctx)
+	defer func() { mc.queryAttrs = nil }()
+	var rows *textRows
+	err = mc.retry(ctx, mc.cfg.MultiStatements, func() (err error) {
+		if err := mc.watchCancel(ctx); err != nil {
+			return err
+		}
+		done := mc.traceQuery(ctx, query, len(dargs))
+		rows, err = mc.query(query, dargs)
+		done(nil, err)
+		if err != nil {
+			mc.finish()
+		}
+		return err
+	})
 	if err != nil {
-		mc.finish()
 		return nil, err
 	}
 	rows.finish = mc.finish
@@ -521,13 +852,24 @@ func (mc *mysqlConn) ExecContext(ctx context.Context, query string, args []drive
 	if err != nil {
 		return nil, err
 	}
-
-	if err := mc.watchCancel(ctx); err != nil {
-		return nil, err
+	if len(dargs) != 0 && !mc.cfg.InterpolateParams {
+		return nil, driver.ErrSkip // not traced, database/sql prepares the statement instead
 	}
-	defer mc.finish()
 
-	return mc.Exec(query, dargs)
+	mc.queryAttrs = queryAttributes(ctx)
+	defer func() { mc.queryAttrs = nil }()
+	var res driver.Result
+	err = mc.retry(ctx, mc.cfg.MultiStatements, func() (err error) {
+		if err := mc.watchCancel(ctx); err != nil {
+			return err
+		}
+		defer mc.finish()
+		done := mc.traceQuery(ctx, query, len(dargs))
+		res, err = mc.Exec(query, dargs)
+		done(res, err)
+		return err
+	})
+	return res, err
 }
 
 func (mc *mysqlConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
@@ -535,8 +877,10 @@ func (mc *mysqlConn) PrepareContext(ctx context.Context, query string) (driver.S
 		return nil, err
 	}
 
+	start := time.Now()
 	stmt, err := mc.Prepare(query)
 	mc.finish()
+	mc.tracePrepare(ctx, query, start, err)
 	if err != nil {
 		return nil, err
 	}
@@ -556,14 +900,31 @@ func (stmt *mysqlStmt) QueryContext(ctx context.Context, args []driver.NamedValu
 		return nil, err
 	}
 
-	if err := stmt.mc.watchCancel(ctx); err != nil {
+	mc := stmt.mc
+	mc.queryAttrs = queryAttributes(ctx)
+	defer func() { mc.queryAttrs = nil }()
+	var rows *binaryRows
+	err = mc.retry(ctx, false, func() (err error) {
+		if err := mc.watchCancel(ctx); err != nil {
+			return err
+		}
+		done := mc.traceQuery(ctx, stmt.sql, len(dargs))
+		rows, err = stmt.query(dargs)
+		done(nil, err)
+		if err != nil {
+			mc.finish()
+		}
+		return err
+	})
+	if err != nil {
 		return nil, err
 	}
-
-	rows, err := stmt.query(dargs)
-	if err != nil {
+	if rows.cursor != nil {
+		// A cursor leaves no unread packets on the connection, which may be
+		// used by other commands until the next fetch. Stop watching ctx now
+		// so these commands can watch their own contexts.
 		stmt.mc.finish()
-		return nil, err
+		return rows, err
 	}
 	rows.finish = stmt.mc.finish
 	return rows, err
@@ -575,12 +936,21 @@ func (stmt *mysqlStmt) ExecContext(ctx context.Context, args []driver.NamedValue
 		return nil, err
 	}
 
-	if err := stmt.mc.watchCancel(ctx); err != nil {
-		return nil, err
-	}
-	defer stmt.mc.finish()
-
-	return stmt.Exec(dargs)
+	mc := stmt.mc
+	mc.queryAttrs = queryAttributes(ctx)
+	defer func() { mc.queryAttrs = nil }()
+	var res driver.Result
+	err = mc.retry(ctx, false, func() (err error) {
+		if err := mc.watchCancel(ctx); err != nil {
+			return err
+		}
+		defer mc.finish()
+		done := mc.traceQuery(ctx, stmt.sql, len(dargs))
+		res, err = stmt.Exec(dargs)
+		done(res, err)
+		return err
+	})
+	return res, err
 }
 
 func (mc *mysqlConn) watchCancel(ctx context.Context) error {
@@ -641,7 +1011,8 @@ func (mc *mysqlConn) CheckNamedValue(nv *driver.NamedValue) (err error) {
 // ResetSession implements driver.SessionResetter.
 // (From Go 1.10)
 func (mc *mysqlConn) ResetSession(ctx context.Context) error {
-	if mc.closed.Load() || mc.buf.busy() {
+	if mc.closed.Load() || mc.busy() {
+		mc.collectBadConn()
 		return driver.ErrBadConn
 	}
 
@@ -664,7 +1035,21 @@ func (mc *mysqlConn) ResetSession(ctx context.Context) error {
 			err = connCheck(conn)
 		}
 		if err != nil {
-			mc.log("closing bad idle connection: ", err)
+			mc.logEvent(slog.LevelWarn, "closing bad idle connection", err)
+			mc.collectBadConn()
+			return driver.ErrBadConn
+		}
+	}
+
+	if mc.cfg.resetSessionOnReuse {
+		if err := mc.watchCancel(ctx); err != nil {
+			return err
+		}
+		err := mc.resetSession()
+		mc.finish()
+		if err != nil {
+			mc.logEvent(slog.LevelWarn, "closing connection after failed session reset", err)
+			mc.collectBadConn()
 			return driver.ErrBadConn
 		}
 	}
@@ -675,8 +1060,9 @@ func (mc *mysqlConn) ResetSession(ctx context.Context) error {
 // IsValid implements driver.Validator interface
 // (From Go 1.15)
 func (mc *mysqlConn) IsValid() bool {
-	return !mc.closed.Load() && !mc.buf.busy()
+	return !mc.closed.Load() && !mc.busy()
 }
 
+var _ Conn = &mysqlConn{}
 var _ driver.SessionResetter = &mysqlConn{}
 var _ driver.Validator = &mysqlConn{}
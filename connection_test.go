@@ -8,12 +8,14 @@
 package mysql
 
 import (
+	"bytes"
 	"context"
 	"database/sql/driver"
 	"encoding/json"
 	"errors"
 	"net"
 	"testing"
+	"time"
 )
 
 func TestInterpolateParams(t *testing.T) {
@@ -156,6 +158,44 @@ func TestCleanCancel(t *testing.T) {
 	}
 }
 
+// TestQueryContextCancellable, iptal edilebilir bir bağlamla sorgu ve exec
+// çalıştırır. Bağlantı açık kalmalıdır.
+func TestQueryContextCancellable(t *testing.T) {
+	timeoutCtx, cancelTimeout := context.WithTimeout(context.Background(), time.Minute)
+	defer cancelTimeout()
+	cancelCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	for _, ctx := range []context.Context{cancelCtx, timeoutCtx} {
+		conn, mc := newRWMockConn(0)
+		mc.startWatcher()
+		eof := []byte{iEOF, 0x00, 0x00, 0x02, 0x00}
+		conn.queuedReplies = [][]byte{
+			makePackets(1, []byte{0x01}, makeColumnDefinition("1", fieldTypeLongLong), eof, []byte{0x01, '1'}, eof),
+			makePackets(1, []byte{iOK, 0x00, 0x00, 0x02, 0x00, 0x00, 0x00}),
+		}
+
+		rows, err := mc.QueryContext(ctx, "SELECT 1", nil)
+		if err != nil {
+			t.Fatalf("beklenmeyen hata: %v", err)
+		}
+		dest := make([]driver.Value, 1)
+		if err := rows.Next(dest); err != nil {
+			t.Fatalf("beklenmeyen hata: %v", err)
+		}
+		if err := rows.Close(); err != nil {
+			t.Fatalf("beklenmeyen hata: %v", err)
+		}
+		if _, err := mc.ExecContext(ctx, "DO 1", nil); err != nil {
+			t.Fatalf("beklenmeyen hata: %v", err)
+		}
+		if mc.closed.Load() || mc.watching {
+			t.Errorf("bağlantı kapalı %v, watching %v", mc.closed.Load(), mc.watching)
+		}
+		mc.cleanup()
+	}
+}
+
 func TestPingMarkBadConnection(t *testing.T) {
 	nc := badConnection{err: errors.New("boom")}
 	mc := &mysqlConn{
@@ -203,3 +243,170 @@ func (bc badConnection) Write(b []byte) (n int, err error) {
 func (bc badConnection) Close() error {
 	return nil
 }
+
+func newResetSessionConn() (*mockConn, *mysqlConn) {
+	conn, mc := newRWMockConn(0)
+	mc.cfg.CheckConnLiveness = false
+	mc.cfg.resetSessionOnReuse = true
+	mc.cfg.Params = map[string]string{"autocommit": "0"}
+	return conn, mc
+}
+
+func TestResetSessionOnReuse(t *testing.T) {
+	conn, mc := newResetSessionConn()
+	mc.session.SystemVariables = map[string]string{"autocommit": "OFF"}
+	ok := []byte{iOK, 0x00, 0x00, 0x02, 0x00, 0x00, 0x00}
+	conn.queuedReplies = [][]byte{makePackets(1, ok), makePackets(1, ok)}
+
+	if err := mc.ResetSession(context.Background()); err != nil {
+		t.Fatalf("beklenen err=nil, alınan %#v", err)
+	}
+
+	expected := append(makePackets(0, []byte{comResetConnection}),
+		makePackets(0, append([]byte{comQuery}, "SET autocommit = 0"...))...)
+	if !bytes.Equal(conn.written, expected) {
+		t.Errorf("beklenen %q, alınan %q", expected, conn.written)
+	}
+	if mc.session.SystemVariables != nil {
+		t.Errorf("oturum durumu sıfırlanmadı: %v", mc.session.SystemVariables)
+	}
+	if mc.sessionResets != 1 {
+		t.Errorf("beklenen sessionResets=1, alınan %d", mc.sessionResets)
+	}
+}
+
+func TestResetSessionFallbackToChangeUser(t *testing.T) {
+	conn, mc := newResetSessionConn()
+	mc.cfg.User = "gopher"
+	mc.cfg.Passwd = "secret"
+	mc.scramble = []byte("01234567890123456789")
+	mc.authPlugin = "mysql_native_password"
+
+	unknownCommand := append([]byte{iERR, 0x17, 0x04, '#', '0', '8', 'S', '0', '1'}, "Unknown command"...)
+	ok := []byte{iOK, 0x00, 0x00, 0x02, 0x00, 0x00, 0x00}
+	conn.queuedReplies = [][]byte{makePackets(1, unknownCommand), makePackets(1, ok), makePackets(1, ok)}
+
+	if err := mc.ResetSession(context.Background()); err != nil {
+		t.Fatalf("beklenen err=nil, alınan %#v", err)
+	}
+
+	changeUser := append([]byte{comChangeUser}, "gopher\x00"...)
+	changeUser = append(changeUser, 20)
+	changeUser = append(changeUser, scramblePassword(mc.scramble, "secret")...)
+	changeUser = append(changeUser, 0x00, defaultCollationID, 0x00)
+	changeUser = append(changeUser, "mysql_native_password\x00"...)
+
+	expected := makePackets(0, []byte{comResetConnection})
+	expected = append(expected, makePackets(0, changeUser)...)
+	expected = append(expected, makePackets(0, append([]byte{comQuery}, "SET autocommit = 0"...))...)
+	if !bytes.Equal(conn.written, expected) {
+		t.Errorf("beklenen %q, alınan %q", expected, conn.written)
+	}
+}
+
+func TestResetSessionFailureMarksBadConn(t *testing.T) {
+	conn, mc := newResetSessionConn()
+	accessDenied := append([]byte{iERR, 0x15, 0x04, '#', '2', '8', '0', '0', '0'}, "Access denied"...)
+	conn.queuedReplies = [][]byte{makePackets(1, accessDenied)}
+
+	if err := mc.ResetSession(context.Background()); err != driver.ErrBadConn {
+		t.Errorf("beklenen driver.ErrBadConn, alınan %#v", err)
+	}
+}
+
+func TestStmtPreparedAgainAfterReset(t *testing.T) {
+	conn, mc := newRWMockConn(0)
+	stmt := &mysqlStmt{mc: mc, id: 1, sql: "DO 1"}
+	mc.sessionResets = 1
+
+	prepareOk := []byte{iOK, 0x02, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}
+	ok := []byte{iOK, 0x00, 0x00, 0x02, 0x00, 0x00, 0x00}
+	conn.queuedReplies = [][]byte{makePackets(1, prepareOk), makePackets(1, ok)}
+
+	if _, err := stmt.Exec(nil); err != nil {
+		t.Fatalf("beklenen err=nil, alınan %#v", err)
+	}
+	if stmt.id != 2 || stmt.resets != 1 {
+		t.Errorf("ifade yeniden hazırlanmadı: id=%d resets=%d", stmt.id, stmt.resets)
+	}
+	if !bytes.HasPrefix(conn.written, makePackets(0, append([]byte{comStmtPrepare}, "DO 1"...))) {
+		t.Errorf("beklenen COM_STMT_PREPARE, alınan %q", conn.written)
+	}
+}
+
+func TestChangeUser(t *testing.T) {
+	conn, mc := newRWMockConn(0)
+	mc.scramble = []byte("01234567890123456789")
+	mc.authPlugin = "mysql_native_password"
+	mc.sessionResets = 3
+
+	// The server switches to mysql_native_password with a new scramble.
+	scramble := []byte("abcdefghijabcdefghij")
+	authSwitch := append([]byte{iEOF}, "mysql_native_password\x00"...)
+	authSwitch = append(append(authSwitch, scramble...), 0x00)
+	ok := []byte{iOK, 0x00, 0x00, 0x02, 0x00, 0x00, 0x00}
+	conn.queuedReplies = [][]byte{makePackets(1, authSwitch), makePackets(3, ok)}
+
+	if err := mc.ChangeUser(context.Background(), "tenant", "secret", "tenantdb"); err != nil {
+		t.Fatalf("beklenen err=nil, alınan %#v", err)
+	}
+
+	changeUser := append([]byte{comChangeUser}, "tenant\x00"...)
+	changeUser = append(changeUser, 20)
+	changeUser = append(changeUser, scramblePassword([]byte("01234567890123456789"), "secret")...)
+	changeUser = append(changeUser, "tenantdb\x00"...)
+	changeUser = append(changeUser, defaultCollationID, 0x00)
+	changeUser = append(changeUser, "mysql_native_password\x00"...)
+
+	expected := append(makePackets(0, changeUser), makePackets(2, scramblePassword(scramble, "secret"))...)
+	if !bytes.Equal(conn.written, expected) {
+		t.Errorf("beklenen %q, alınan %q", expected, conn.written)
+	}
+	if mc.cfg.User != "tenant" || mc.cfg.DBName != "tenantdb" {
+		t.Errorf("yapılandırma güncellenmedi: %s@%s", mc.cfg.User, mc.cfg.DBName)
+	}
+	if schema := mc.SessionState().Schema; schema != "tenantdb" {
+		t.Errorf("beklenen şema tenantdb, alınan %q", schema)
+	}
+	if mc.sessionResets != 4 {
+		t.Errorf("beklenen sessionResets=4, alınan %d", mc.sessionResets)
+	}
+}
+
+func TestChangeUserAccessDenied(t *testing.T) {
+	conn, mc := newRWMockConn(0)
+	mc.scramble = []byte("01234567890123456789")
+	mc.authPlugin = "mysql_native_password"
+
+	accessDenied := append([]byte{iERR, 0x15, 0x04, '#', '2', '8', '0', '0', '0'}, "Access denied"...)
+	conn.queuedReplies = [][]byte{makePackets(1, accessDenied)}
+
+	err := mc.ChangeUser(context.Background(), "tenant", "wrong", "")
+	var mysqlErr *MySQLError
+	if !errors.As(err, &mysqlErr) || mysqlErr.Number != 1045 {
+		t.Fatalf("beklenen hata 1045, alınan %#v", err)
+	}
+	if !mc.closed.Load() {
+		t.Error("bağlantı kapatılmadı")
+	}
+}
+
+func TestChangeUserInTransaction(t *testing.T) {
+	for _, setup := range []func(mc *mysqlConn){
+		func(mc *mysqlConn) { mc.inTx = true },
+		func(mc *mysqlConn) { mc.status = statusInTrans }, // START TRANSACTION
+		func(mc *mysqlConn) { mc.xaState = xaActive },
+	} {
+		conn, mc := newRWMockConn(0)
+		setup(mc)
+		if err := mc.ChangeUser(context.Background(), "tenant", "secret", ""); err != errChangeUserInTx {
+			t.Errorf("beklenen errChangeUserInTx, alınan %v", err)
+		}
+		if len(conn.written) != 0 {
+			t.Errorf("komut gönderildi: %q", conn.written)
+		}
+		if mc.closed.Load() {
+			t.Error("bağlantı kapatıldı")
+		}
+	}
+}
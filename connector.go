@@ -11,15 +11,22 @@ package mysql
 import (
 	"context"
 	"database/sql/driver"
+	"errors"
+	"fmt"
+	"log/slog"
 	"net"
 	"os"
 	"strconv"
 	"strings"
+	"sync/atomic"
+	"time"
 )
 
 type connector struct {
-	cfg               *Config // değiştirilemez özel kopya.
-	encodedAttributes string  // Kodlanmış bağlantı özellikleri.
+	cfg               *Config       // değiştirilemez özel kopya.
+	encodedAttributes string        // Kodlanmış bağlantı özellikleri.
+	nextHost          atomic.Uint32 // roundrobin için bir sonraki başlangıç hostu
+	srv               srvCache      // srv ağı için önbelleğe alınmış SRV kayıtları
 }
 
 func encodeConnectionAttributes(cfg *Config) string {
@@ -64,18 +71,80 @@ func newConnector(cfg *Config) *connector {
 // Connect, driver.Connector arayüzünü uygular.
 // Connect, veritabanına bir bağlantı döndürür.
 func (c *connector) Connect(ctx context.Context) (driver.Conn, error) {
-	var err error
-
 	// beforeConnect varsa çağır, yapılandırmanın bir kopyası ile
 	cfg := c.cfg
 	if c.cfg.beforeConnect != nil {
 		cfg = c.cfg.Clone()
-		err = c.cfg.beforeConnect(ctx, cfg)
-		if err != nil {
+		if err := c.cfg.beforeConnect(ctx, cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	addrs, err := c.hostOrder(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if len(addrs) == 1 && cfg.Net != srvNet {
+		return c.connectHost(ctx, cfg)
+	}
+
+	// Hostları sırayla dene, ulaşılamayan veya türü uymayan hostları atla
+	var errs []error
+	for _, addr := range addrs {
+		hostCfg := cfg.Clone()
+		hostCfg.Addr = addr
+		if cfg.Net == srvNet {
+			hostCfg.Net = "tcp"
+		}
+		if hostCfg.TLS != nil && hostCfg.TLS.ServerName == "" && !hostCfg.TLS.InsecureSkipVerify {
+			hostCfg.TLS.ServerName, _, _ = net.SplitHostPort(addr)
+		}
+
+		mc, err := c.connectHost(ctx, hostCfg)
+		if err == nil {
+			return mc, nil
+		}
+		if ctx.Err() != nil {
 			return nil, err
 		}
+		errs = append(errs, fmt.Errorf("%s: %w", addr, err))
 	}
+	return nil, errors.Join(errs...)
+}
 
+// connectHost, cfg.Addr adresindeki tek bir hosta bağlanır.
+func (c *connector) connectHost(ctx context.Context, cfg *Config) (driver.Conn, error) {
+	if cfg.passwords == nil {
+		return c.connect(ctx, cfg)
+	}
+
+	// Parolayı sağlayıcıdan al. Sunucu önbellekteki parolayı reddederse,
+	// örneğin süresi dolmuş bir token nedeniyle, yeni bir parola ile bir kez
+	// daha dene. Sağlayıcıdan yeni alınmış bir parola tekrar istenmez.
+	password, fetched, err := cfg.passwords.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	mc, err := c.connectWithPassword(ctx, cfg, password)
+	var mysqlErr *MySQLError
+	if !fetched && errors.As(err, &mysqlErr) && mysqlErr.Number == 1045 { // ER_ACCESS_DENIED_ERROR
+		if password, err = cfg.passwords.refresh(ctx, password); err != nil {
+			return nil, err
+		}
+		mc, err = c.connectWithPassword(ctx, cfg, password)
+	}
+	return mc, err
+}
+
+// connectWithPassword, parola sağlayıcısının parolası ile bağlanır.
+func (c *connector) connectWithPassword(ctx context.Context, cfg *Config, password string) (driver.Conn, error) {
+	cfg = cfg.Clone()
+	cfg.Passwd = password
+	return c.connect(ctx, cfg)
+}
+
+// connect, verilen yapılandırma ile yeni bir bağlantı kurar.
+func (c *connector) connect(ctx context.Context, cfg *Config) (conn driver.Conn, err error) {
 	// Yeni mysqlConn oluştur
 	mc := &mysqlConn{
 		maxAllowedPacket: maxPacketSize,
@@ -85,8 +154,21 @@ func (c *connector) Connect(ctx context.Context) (driver.Conn, error) {
 		connector:        c,
 	}
 	mc.parseTime = mc.cfg.ParseTime
+	mc.metrics = mc.cfg.metrics
+	mc.session.Schema = mc.cfg.DBName
+
+	// Tracer'a bağlantı kurulumunu ve aşamalarını bildir
+	if t := cfg.tracer; t != nil {
+		ctx = t.OnConnectStart(ctx, cfg.Addr)
+		defer func() { t.OnConnectDone(ctx, err) }()
+		mc.connectTrace = func(phase ConnectPhase, start time.Time, err error) {
+			t.OnConnectPhase(ctx, phase, start, time.Now(), err)
+		}
+		defer func() { mc.connectTrace = nil }()
+	}
 
 	// Sunucuya Bağlan
+	dialStart := time.Now()
 	dctx := ctx
 	if mc.cfg.Timeout > 0 {
 		var cancel context.CancelFunc
@@ -107,6 +189,7 @@ func (c *connector) Connect(ctx context.Context) (driver.Conn, error) {
 			mc.netConn, err = nd.DialContext(dctx, mc.cfg.Net, mc.cfg.Addr)
 		}
 	}
+	mc.traceConnectPhase(ConnectPhaseDial, dialStart, err)
 	if err != nil {
 		return nil, err
 	}
@@ -115,7 +198,7 @@ func (c *connector) Connect(ctx context.Context) (driver.Conn, error) {
 	// TCP bağlantılarında TCP Keepalives etkinleştir
 	if tc, ok := mc.netConn.(*net.TCPConn); ok {
 		if err := tc.SetKeepAlive(true); err != nil {
-			c.cfg.Logger.Print(err)
+			mc.logEvent(slog.LevelWarn, "enabling TCP keepalive failed", err)
 		}
 	}
 
@@ -143,33 +226,47 @@ func (c *connector) Connect(ctx context.Context) (driver.Conn, error) {
 	if plugin == "" {
 		plugin = defaultAuthPlugin
 	}
+	mc.scramble = authData
 
 	// İstemci Kimlik Doğrulama Paketi Gönder
+	authStart := time.Now()
 	authResp, err := mc.auth(authData, plugin)
 	if err != nil {
 		// istenen eklentiyi kullanmak başarısız olursa varsayılan kimlik doğrulama eklentisini dene
-		c.cfg.Logger.Print("istenen kimlik doğrulama eklentisi '"+plugin+"' kullanılamadı: ", err.Error())
+		mc.logEvent(slog.LevelWarn, "requested auth plugin failed", err, slog.String("plugin", plugin))
 		plugin = defaultAuthPlugin
 		authResp, err = mc.auth(authData, plugin)
 		if err != nil {
+			mc.traceConnectPhase(ConnectPhaseAuth, authStart, err)
 			mc.cleanup()
 			return nil, err
 		}
 	}
+	mc.authPlugin = plugin
 	if err = mc.writeHandshakeResponsePacket(authResp, plugin); err != nil {
+		mc.traceConnectPhase(ConnectPhaseAuth, authStart, err)
 		mc.cleanup()
 		return nil, err
 	}
 
 	// kimlik doğrulama paketine yanıtı işle, mümkünse yöntemleri değiştir
-	if err = mc.handleAuthResult(authData, plugin); err != nil {
+	err = mc.handleAuthResult(authData, plugin)
+	mc.traceConnectPhase(ConnectPhaseAuth, authStart, err)
+	handshake := time.Since(dialStart)
+	if err != nil {
 		// Kimlik doğrulama başarısız oldu ve MySQL bağlantıyı zaten kapattı
 		// (https://dev.mysql.com/doc/internals/en/authentication-fails.html).
 		// COM_QUIT göndermeyin, sadece temizleyin ve hatayı döndürün.
+		mc.logEvent(slog.LevelInfo, "authentication failed", err)
 		mc.cleanup()
 		return nil, err
 	}
 
+	// Kimlik doğrulamadan sonra sıkıştırılmış protokole geç
+	if mc.compressor != nil {
+		mc.startCompression()
+	}
+
 	if mc.cfg.MaxAllowedPacket > 0 {
 		mc.maxAllowedPacket = mc.cfg.MaxAllowedPacket
 	} else {
@@ -185,32 +282,22 @@ func (c *connector) Connect(ctx context.Context) (driver.Conn, error) {
 		mc.maxWriteSize = mc.maxAllowedPacket
 	}
 
-	// Charset: character_set_connection, character_set_client, character_set_results
-	if len(mc.cfg.charsets) > 0 {
-		for _, cs := range mc.cfg.charsets {
-			// burada hataları yoksay - bir karakter seti mevcut olmayabilir
-			if mc.cfg.Collation != "" {
-				err = mc.exec("SET NAMES " + cs + " COLLATE " + mc.cfg.Collation)
-			} else {
-				err = mc.exec("SET NAMES " + cs)
-			}
-			if err == nil {
-				break
-			}
-		}
-		if err != nil {
-			mc.Close()
-			return nil, err
-		}
+	// Karakter setini, collation'ı ve DSN parametrelerini uygula
+	if err = mc.initSession(); err != nil {
+		mc.Close()
+		return nil, err
 	}
 
-	// DSN Parametrelerini İşle
-	err = mc.handleParams()
-	if err != nil {
+	// Sunucu istenen türde değilse (birincil/replika) bağlantıyı kapat
+	if err = mc.checkServerType(); err != nil {
+		mc.logEvent(slog.LevelInfo, "server type does not match targetServerType", err)
 		mc.Close()
 		return nil, err
 	}
 
+	mc.logEvent(slog.LevelInfo, "connected", nil)
+	mc.collectConnected(handshake)
+
 	return mc, nil
 }
 
@@ -1,8 +1,15 @@
 package mysql
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"io"
 	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -28,3 +35,201 @@ func TestConnectorReturnsTimeout(t *testing.T) {
 		t.Fatalf("expected %T, got %T", nerr, err)
 	}
 }
+
+// handshakeV10 is an initial handshake packet offering mysql_native_password.
+var handshakeV10 = []byte{10, 53, 46, 53, 46, 56, 0, 165, 0, 0, 0,
+	60, 70, 63, 58, 68, 104, 34, 97, 0, 223, 247, 33, 2, 0, 15, 128, 21, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 98, 120, 114, 47, 85, 75, 109, 99, 51, 77,
+	50, 64, 0, 109, 121, 115, 113, 108, 95, 110, 97, 116, 105, 118, 101, 95,
+	112, 97, 115, 115, 119, 111, 114, 100}
+
+var handshakeV10Scramble = []byte{60, 70, 63, 58, 68, 104, 34, 97, 98, 120, 114,
+	47, 85, 75, 109, 99, 51, 77, 50, 64}
+
+// fakeAuthServer accepts a single connection if the client authenticates
+// with mysql_native_password and the given password.
+func fakeAuthServer(conn net.Conn, password string) {
+	fakeServer(conn, password, nil)
+}
+
+// fakeServer works like fakeAuthServer, but afterwards answers
+// "SELECT @@name" queries with the values of vars.
+func fakeServer(conn net.Conn, password string, vars map[string]string) {
+	defer conn.Close()
+	if _, err := conn.Write(makePackets(0, handshakeV10)); err != nil {
+		return
+	}
+
+	var header [4]byte
+	if _, err := io.ReadFull(conn, header[:]); err != nil {
+		return
+	}
+	resp := make([]byte, int(header[0])|int(header[1])<<8|int(header[2])<<16)
+	if _, err := io.ReadFull(conn, resp); err != nil {
+		return
+	}
+
+	// skip flags, max packet size, charset, filler and user name
+	pos := 4 + 4 + 1 + 23
+	pos += bytes.IndexByte(resp[pos:], 0) + 1
+	authResp := resp[pos+1 : pos+1+int(resp[pos])]
+
+	if !bytes.Equal(authResp, scramblePassword(handshakeV10Scramble, password)) {
+		conn.Write(makePackets(2, append([]byte{iERR, 0x15, 0x04, '#', '2', '8', '0', '0', '0'}, "Access denied"...)))
+		return
+	}
+	if _, err := conn.Write(makePackets(2, []byte{iOK, 0x00, 0x00, 0x02, 0x00, 0x00, 0x00})); err != nil {
+		return
+	}
+
+	for {
+		if _, err := io.ReadFull(conn, header[:]); err != nil {
+			return
+		}
+		cmd := make([]byte, int(header[0])|int(header[1])<<8|int(header[2])<<16)
+		if _, err := io.ReadFull(conn, cmd); err != nil || cmd[0] != comQuery {
+			return // COM_QUIT
+		}
+		name := strings.TrimPrefix(string(cmd[1:]), "SELECT @@")
+		value := vars[name]
+		eof := []byte{iEOF, 0x00, 0x00, 0x02, 0x00}
+		reply := makePackets(1, []byte{0x01}, makeColumnDefinition("@@"+name, fieldTypeVarString),
+			eof, append([]byte{byte(len(value))}, value...), eof)
+		if _, err := conn.Write(reply); err != nil {
+			return
+		}
+	}
+}
+
+type countingPasswordProvider struct {
+	calls     int
+	passwords []string
+}
+
+func (p *countingPasswordProvider) Password(ctx context.Context) (string, time.Time, error) {
+	password := p.passwords[p.calls]
+	p.calls++
+	return password, time.Now().Add(time.Hour), nil
+}
+
+// newPasswordProviderConnector returns a connector using provider for a
+// server which accepts the password "token".
+func newPasswordProviderConnector(t *testing.T, provider PasswordProvider) (*Config, *connector) {
+	t.Helper()
+	cfg := NewConfig()
+	cfg.User = "iam"
+	cfg.MaxAllowedPacket = defaultMaxAllowedPacket
+	cfg.DialFunc = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		client, server := net.Pipe()
+		go fakeAuthServer(server, "token")
+		return client, nil
+	}
+	if err := cfg.Apply(UsePasswordProvider(provider)); err != nil {
+		t.Fatal(err)
+	}
+	return cfg, newConnector(cfg)
+}
+
+func TestConnectorPasswordProviderRetry(t *testing.T) {
+	provider := &countingPasswordProvider{passwords: []string{"expired", "token", "unused"}}
+	cfg, connector := newPasswordProviderConnector(t, provider)
+	// cache the password which the server rejects
+	if _, _, err := cfg.passwords.get(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		conn, err := connector.Connect(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		conn.Close()
+	}
+
+	// the rejected password was refreshed once, the new one was cached
+	if provider.calls != 2 {
+		t.Errorf("expected 2 calls of the provider, got %d", provider.calls)
+	}
+	if cfg.Passwd != "" {
+		t.Errorf("the shared config must not be modified, got password %q", cfg.Passwd)
+	}
+}
+
+func TestConnectorPasswordProviderNoRetryAfterFetch(t *testing.T) {
+	provider := &countingPasswordProvider{passwords: []string{"wrong", "unused"}}
+	_, connector := newPasswordProviderConnector(t, provider)
+
+	_, err := connector.Connect(context.Background())
+	var mysqlErr *MySQLError
+	if !errors.As(err, &mysqlErr) || mysqlErr.Number != 1045 {
+		t.Fatalf("expected access denied, got %v", err)
+	}
+	// a password which has just been fetched is not fetched again
+	if provider.calls != 1 {
+		t.Errorf("expected 1 call of the provider, got %d", provider.calls)
+	}
+}
+
+func TestPasswordCacheExpiry(t *testing.T) {
+	expiry := time.Now().Add(-time.Second)
+	calls := 0
+	cache := &passwordCache{provider: PasswordProviderFunc(func(ctx context.Context) (string, time.Time, error) {
+		calls++
+		return fmt.Sprint("token", calls), expiry, nil
+	})}
+
+	// an expired password is requested again
+	cache.get(context.Background())
+	if _, fetched, _ := cache.get(context.Background()); !fetched || calls != 2 {
+		t.Errorf("expected 2 calls, got %d", calls)
+	}
+
+	// a password without expiry is cached until it is refreshed
+	expiry = time.Time{}
+	cache.refresh(context.Background(), "token2")
+	if password, fetched, _ := cache.get(context.Background()); fetched || password != "token3" || calls != 3 {
+		t.Errorf("expected the cached token3 after 3 calls, got %q after %d calls", password, calls)
+	}
+
+	// a password which has already been refreshed is not refreshed again
+	if password, _ := cache.refresh(context.Background(), "token2"); password != "token3" || calls != 3 {
+		t.Errorf("expected the cached token3 after 3 calls, got %q after %d calls", password, calls)
+	}
+}
+
+func TestPasswordCacheSingleFlight(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	cache := &passwordCache{provider: PasswordProviderFunc(func(ctx context.Context) (string, time.Time, error) {
+		calls.Add(1)
+		<-release
+		return "token", time.Time{}, nil
+	})}
+
+	const n = 10
+	var wg sync.WaitGroup
+	passwords := make([]string, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			passwords[i], _ = cache.refresh(context.Background(), "expired")
+		}(i)
+	}
+	// wait until all callers are waiting for the call in progress
+	for calls.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if calls.Load() != 1 {
+		t.Errorf("expected 1 call of the provider, got %d", calls.Load())
+	}
+	for i, password := range passwords {
+		if password != "token" {
+			t.Errorf("caller %d got password %q", i, password)
+		}
+	}
+}
@@ -27,6 +27,12 @@ const (
 	connAttrPlatformValue   = runtime.GOARCH
 	connAttrPid             = "_pid"
 	connAttrServerHost      = "_server_host"
+
+	// Compressed protocol
+	compressionZlib             = "zlib"
+	compressionZstd             = "zstd"
+	defaultCompressionThreshold = 50 // MIN_COMPRESS_LENGTH of libmysqlclient
+	defaultZstdCompressionLevel = 3
 )
 
 // MySQL constants documentation:
@@ -69,6 +75,9 @@ const (
 	clientCanHandleExpiredPasswords
 	clientSessionTrack
 	clientDeprecateEOF
+	clientOptionalResultsetMetadata
+	clientZstdCompressionAlgorithm
+	clientQueryAttributes
 )
 
 const (
@@ -100,6 +109,19 @@ const (
 	comStmtReset
 	comSetOption
 	comStmtFetch
+	comDaemon
+	comBinlogDumpGTID
+	comResetConnection
+)
+
+// Cursor types of COM_STMT_EXECUTE
+// https://dev.mysql.com/doc/dev/mysql-server/latest/page_protocol_com_stmt_execute.html
+const (
+	cursorTypeNoCursor byte = 0x00
+	cursorTypeReadOnly byte = 0x01
+
+	// parameter_count is sent although the statement has no parameters
+	parameterCountAvailable byte = 0x08
 )
 
 // https://dev.mysql.com/doc/internals/en/com-query-response.html#packet-Protocol::ColumnType
@@ -0,0 +1,189 @@
+// Go MySQL Driver - A MySQL-Driver for Go's database/sql package
+//
+// Copyright 2024 The Go-MySQL-Driver Authors. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package mysql
+
+import (
+	"database/sql/driver"
+	"io"
+)
+
+// cursor is a read-only server-side cursor opened by COM_STMT_EXECUTE.
+//
+// Rows are fetched in batches with COM_STMT_FETCH. A batch is read from the
+// network completely and kept in memory, so the connection is free for other
+// commands between two fetches.
+type cursor struct {
+	stmt      *mysqlStmt
+	fetchSize uint32
+	buf       []byte // row packets of the current batch
+	offsets   []int  // end offset of each row packet in buf
+	next      int    // index of the next row to return
+	exhausted bool   // set when the server has sent the last row
+	checked   bool   // set when the warnings of the last fetch were read
+	warnErr   error  // error of reading the warnings, returned at the end
+}
+
+func newCursor(stmt *mysqlStmt, fetchSize int) *cursor {
+	return &cursor{
+		stmt:      stmt,
+		fetchSize: uint32(fetchSize),
+	}
+}
+
+// fetch requests the next batch of rows.
+// https://dev.mysql.com/doc/dev/mysql-server/latest/page_protocol_com_stmt_fetch.html
+func (c *cursor) fetch(mc *mysqlConn) error {
+	mc.resetSequence()
+	data, err := mc.buf.takeSmallBuffer(4 + 1 + 4 + 4)
+	if err != nil {
+		return err
+	}
+
+	// command [1 byte]
+	data[4] = comStmtFetch
+
+	// statement_id [4 bytes]
+	data[5] = byte(c.stmt.id)
+	data[6] = byte(c.stmt.id >> 8)
+	data[7] = byte(c.stmt.id >> 16)
+	data[8] = byte(c.stmt.id >> 24)
+
+	// num_rows [4 bytes]
+	data[9] = byte(c.fetchSize)
+	data[10] = byte(c.fetchSize >> 8)
+	data[11] = byte(c.fetchSize >> 16)
+	data[12] = byte(c.fetchSize >> 24)
+
+	if err = mc.writePacket(data); err != nil {
+		return err
+	}
+
+	c.buf = c.buf[:0]
+	c.offsets = c.offsets[:0]
+	c.next = 0
+	for {
+		data, err := mc.readPacket()
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case data[0] == iOK:
+			c.buf = append(c.buf, data...)
+			c.offsets = append(c.offsets, len(c.buf))
+		case mc.isEOFPacket(data):
+			if err := mc.handleEOFPacket(data); err != nil {
+				return err
+			}
+			if mc.status&statusLastRowSent != 0 || mc.status&statusCursorExists == 0 {
+				c.exhausted = true
+			}
+			return nil
+		default:
+			return mc.handleErrorPacket(data)
+		}
+	}
+}
+
+// readCursorStatus reads the packet following the column definitions of a
+// result set for which a cursor was requested, if CLIENT_DEPRECATE_EOF is in
+// use. If the server opened the cursor, this is an OK packet carrying
+// SERVER_STATUS_CURSOR_EXISTS. Otherwise the rows are sent right away and the
+// packet is either the first row or the end of an empty result set.
+func (rows *binaryRows) readCursorStatus() error {
+	mc := rows.mc
+	data, err := mc.readPacket()
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case mc.isEOFPacket(data):
+		if err := mc.handleEOFPacket(data); err != nil {
+			return err
+		}
+		if mc.status&statusCursorExists == 0 {
+			rows.rs.done = true
+			if err := rows.readWarnings(mc); err != nil {
+				return err
+			}
+			if !rows.HasNextResultSet() {
+				rows.mc = nil
+			}
+		}
+		return nil
+	case data[0] == iOK:
+		// The first row; data is only valid until the next read.
+		mc.status &^= statusCursorExists
+		rows.pending = append([]byte(nil), data...)
+		return nil
+	default:
+		return mc.handleErrorPacket(data)
+	}
+}
+
+// nextRow returns the next buffered row packet, fetching a new batch if
+// necessary. It returns io.EOF once all rows have been returned.
+func (c *cursor) nextRow(mc *mysqlConn) ([]byte, error) {
+	for c.next >= len(c.offsets) {
+		if c.exhausted {
+			return nil, io.EOF
+		}
+		if err := c.fetch(mc); err != nil {
+			return nil, err
+		}
+	}
+
+	start := 0
+	if c.next > 0 {
+		start = c.offsets[c.next-1]
+	}
+	end := c.offsets[c.next]
+	c.next++
+	return c.buf[start:end], nil
+}
+
+// close closes the cursor on the server if it was not read until the end.
+func (c *cursor) close(mc *mysqlConn) error {
+	if c.exhausted || c.stmt.mc == nil {
+		// Closing the statement closes its cursor, too.
+		return nil
+	}
+	c.exhausted = true
+
+	handleOk := mc.clearResult()
+	if err := mc.writeCommandPacketUint32(comStmtReset, c.stmt.id); err != nil {
+		return err
+	}
+	return handleOk.readResultOK()
+}
+
+func (rows *binaryRows) nextCursorRow(dest []driver.Value) error {
+	mc := rows.mc
+	c := rows.cursor
+	data, err := c.nextRow(mc)
+	if (err == nil || err == io.EOF) && c.exhausted && !c.checked {
+		// The warnings are read right after the last fetch, before the
+		// connection is used for another statement.
+		c.checked = true
+		c.warnErr = rows.readWarnings(mc)
+	}
+	if err == io.EOF {
+		rows.rs.done = true
+		rows.mc = nil
+		if c.warnErr != nil {
+			return c.warnErr
+		}
+		return io.EOF
+	}
+	if err != nil {
+		return err
+	}
+	return rows.parseRow(data, dest)
+}
@@ -0,0 +1,238 @@
+// Go MySQL Driver - A MySQL-Driver for Go's database/sql package
+//
+// Copyright 2024 The Go-MySQL-Driver Authors. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package mysql
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+)
+
+// binary row with a single BIGINT column
+func makeInt64Row(v int64) []byte {
+	row := []byte{iOK, 0x00}
+	return binary.LittleEndian.AppendUint64(row, uint64(v))
+}
+
+func makeEOF(status statusFlag) []byte {
+	return []byte{iEOF, 0x00, 0x00, byte(status), byte(status >> 8)}
+}
+
+func newCursorRows(fetchSize int) (*mockConn, *mysqlConn, *binaryRows) {
+	conn, mc := newRWMockConn(0)
+	stmt := &mysqlStmt{mc: mc, id: 7, columnCount: 1}
+	rows := &binaryRows{cursor: newCursor(stmt, fetchSize)}
+	rows.mc = mc
+	rows.rs.columns = []mysqlField{{fieldType: fieldTypeLongLong}}
+	return conn, mc, rows
+}
+
+func TestCursorFetchesInBatches(t *testing.T) {
+	conn, _, rows := newCursorRows(2)
+	conn.queuedReplies = [][]byte{
+		makePackets(1, makeInt64Row(1), makeInt64Row(2), makeEOF(statusCursorExists)),
+		makePackets(1, makeInt64Row(3), makeEOF(statusCursorExists|statusLastRowSent)),
+	}
+
+	dest := make([]driver.Value, 1)
+	for want := int64(1); want <= 3; want++ {
+		if err := rows.Next(dest); err != nil {
+			t.Fatalf("row %d: %v", want, err)
+		}
+		if dest[0] != want {
+			t.Errorf("expected %d, got %v", want, dest[0])
+		}
+	}
+	if err := rows.Next(dest); err != io.EOF {
+		t.Fatalf("expected io.EOF, got %v", err)
+	}
+
+	fetch := []byte{9, 0, 0, 0, comStmtFetch, 7, 0, 0, 0, 2, 0, 0, 0}
+	if want := append(fetch, fetch...); !bytes.Equal(conn.written, want) {
+		t.Errorf("expected fetch commands %v, got %v", want, conn.written)
+	}
+
+	// the cursor is exhausted, closing must not send anything
+	conn.written = nil
+	if err := rows.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if len(conn.written) != 0 {
+		t.Errorf("unexpected write on close: %v", conn.written)
+	}
+}
+
+func TestCursorCloseResetsStatement(t *testing.T) {
+	conn, _, rows := newCursorRows(1)
+	conn.queuedReplies = [][]byte{
+		makePackets(1, makeInt64Row(1), makeEOF(statusCursorExists)),
+		makePackets(1, []byte{iOK, 0x00, 0x00, 0x02, 0x00, 0x00, 0x00}),
+	}
+
+	dest := make([]driver.Value, 1)
+	if err := rows.Next(dest); err != nil {
+		t.Fatal(err)
+	}
+
+	conn.written = nil
+	if err := rows.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if want := []byte{5, 0, 0, 0, comStmtReset, 7, 0, 0, 0}; !bytes.Equal(conn.written, want) {
+		t.Errorf("expected COM_STMT_RESET %v, got %v", want, conn.written)
+	}
+	if rows.HasNextResultSet() {
+		t.Error("cursor rows must not have further result sets")
+	}
+}
+
+func TestCursorConnectionUsableBetweenFetches(t *testing.T) {
+	conn, mc, rows := newCursorRows(1)
+	conn.queuedReplies = [][]byte{
+		makePackets(1, makeInt64Row(1), makeEOF(statusCursorExists)),
+		makePackets(1, []byte{iOK, 0x00, 0x00, 0x02, 0x00, 0x00, 0x00}),
+		makePackets(1, makeInt64Row(2), makeEOF(statusCursorExists|statusLastRowSent)),
+	}
+
+	dest := make([]driver.Value, 1)
+	if err := rows.Next(dest); err != nil {
+		t.Fatal(err)
+	}
+
+	// another command runs while the cursor is open
+	if err := mc.Ping(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := rows.Next(dest); err != nil {
+		t.Fatal(err)
+	}
+	if dest[0] != int64(2) {
+		t.Errorf("expected 2, got %v", dest[0])
+	}
+	if err := rows.Next(dest); err != io.EOF {
+		t.Fatalf("expected io.EOF, got %v", err)
+	}
+}
+
+func TestCursorFetchError(t *testing.T) {
+	conn, _, rows := newCursorRows(1)
+	conn.queuedReplies = [][]byte{
+		makePackets(1, []byte{iERR, 0x14, 0x05, '#', 'H', 'Y', '0', '0', '0', 'n', 'o'}),
+	}
+
+	dest := make([]driver.Value, 1)
+	err := rows.Next(dest)
+	if me, ok := err.(*MySQLError); !ok || me.Number != 1300 {
+		t.Fatalf("expected MySQLError 1300, got %v", err)
+	}
+}
+
+func TestCursorDeprecateEOF(t *testing.T) {
+	conn, mc := newRWMockConn(0)
+	mc.flags = clientDeprecateEOF
+	mc.cfg.cursorFetchSize = 2
+	stmt := &mysqlStmt{mc: mc, id: 7, columnCount: 1}
+
+	conn.queuedReplies = [][]byte{
+		makePackets(1,
+			[]byte{0x01},
+			makeColumnDefinition("id", fieldTypeLongLong),
+			makeEOFOK(statusCursorExists, nil),
+		),
+		makePackets(1, makeInt64Row(1), makeEOFOK(statusCursorExists|statusLastRowSent, nil)),
+	}
+
+	rows, err := stmt.query(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rows.cursor == nil {
+		t.Fatal("expected a cursor")
+	}
+
+	dest := make([]driver.Value, 1)
+	if err := rows.Next(dest); err != nil {
+		t.Fatal(err)
+	}
+	if dest[0] != int64(1) {
+		t.Errorf("expected 1, got %v", dest[0])
+	}
+	if err := rows.Next(dest); err != io.EOF {
+		t.Fatalf("expected io.EOF, got %v", err)
+	}
+}
+
+func TestCursorDeprecateEOFNotOpened(t *testing.T) {
+	conn, mc := newRWMockConn(0)
+	mc.flags = clientDeprecateEOF
+	mc.cfg.cursorFetchSize = 2
+	stmt := &mysqlStmt{mc: mc, id: 7, columnCount: 1}
+
+	// The server ignores the cursor request and sends the rows right away.
+	conn.queuedReplies = [][]byte{makePackets(1,
+		[]byte{0x01},
+		makeColumnDefinition("id", fieldTypeLongLong),
+		makeInt64Row(1),
+		makeInt64Row(2),
+		makeEOFOK(0, nil),
+	)}
+
+	rows, err := stmt.query(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rows.cursor != nil {
+		t.Fatal("unexpected cursor")
+	}
+
+	dest := make([]driver.Value, 1)
+	for want := int64(1); want <= 2; want++ {
+		if err := rows.Next(dest); err != nil {
+			t.Fatalf("row %d: %v", want, err)
+		}
+		if dest[0] != want {
+			t.Errorf("expected %d, got %v", want, dest[0])
+		}
+	}
+	if err := rows.Next(dest); err != io.EOF {
+		t.Fatalf("expected io.EOF, got %v", err)
+	}
+}
+
+func TestCursorWarningsAsErrors(t *testing.T) {
+	conn, mc, rows := newCursorRows(2)
+	mc.cfg.warningsAsErrors = true
+	lastFetch := makeEOF(statusCursorExists | statusLastRowSent)
+	lastFetch[1] = 1 // 1 warning
+	conn.queuedReplies = [][]byte{
+		makePackets(1, makeInt64Row(1), lastFetch),
+		showWarningsReply(Warning{Level: "Warning", Code: 1264, Message: "Out of range value"}),
+	}
+
+	dest := make([]driver.Value, 1)
+	if err := rows.Next(dest); err != nil {
+		t.Fatal(err)
+	}
+	err := rows.Next(dest)
+	var warnings *MySQLWarnings
+	if !errors.As(err, &warnings) || len(warnings.Warnings) != 1 || warnings.Warnings[0].Code != 1264 {
+		t.Fatalf("expected *MySQLWarnings with code 1264, got %v", err)
+	}
+	if n := rows.WarningCount(); n != 1 {
+		t.Errorf("expected 1 warning, got %d", n)
+	}
+	if !bytes.Contains(conn.written, []byte("SHOW WARNINGS")) {
+		t.Error("warnings were not read")
+	}
+}
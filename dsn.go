@@ -15,6 +15,7 @@ import (
 	"crypto/tls"
 	"errors"
 	"fmt"
+	"log/slog"
 	"math/big"
 	"net"
 	"net/url"
@@ -68,6 +69,7 @@ type Config struct {
 	CheckConnLiveness        bool // Check connections for liveness before using them
 	ClientFoundRows          bool // Return number of matching rows instead of rows changed
 	ColumnsWithAlias         bool // Prepend table alias to column names
+	Compress                 bool // Use the compressed protocol if the server supports it
 	InterpolateParams        bool // Interpolate placeholders into query string
 	MultiStatements          bool // Allow multiple statements in one query
 	ParseTime                bool // Parse time values to time.Time
@@ -75,9 +77,27 @@ type Config struct {
 
 	// unexported fields. new options should be come here
 
-	beforeConnect func(context.Context, *Config) error // Invoked before a connection is established
-	pubKey        *rsa.PublicKey                       // Server public key
-	timeTruncate  time.Duration                        // Truncate time.Time values to the specified duration
+	beforeConnect        func(context.Context, *Config) error // Invoked before a connection is established
+	pubKey               *rsa.PublicKey                       // Server public key
+	timeTruncate         time.Duration                        // Truncate time.Time values to the specified duration
+	compressionAlgorithm string                               // Compression algorithm, "zlib" (default) or "zstd"
+	compressionLevel     int                                  // Compression level, 0 selects the algorithm's default
+	compressionThreshold int                                  // Minimum payload size to compress, 0 selects the default
+	cursorFetchSize      int                                  // Rows per COM_STMT_FETCH of server-side cursors, 0 disables cursors
+	resetSessionOnReuse  bool                                 // Reset the session state before a pooled connection is reused
+	passwords            *passwordCache                       // Provides Passwd for each connection attempt
+	hostSelection        string                               // Order in which the hosts of Addr are tried
+	targetServerType     string                               // "primary", "replica" or "any" (default)
+	srvResolver          SRVResolver                          // Resolves the SRV records of the "srv" network
+	srvTTL               time.Duration                        // Time SRV records are cached
+	slogger              *slog.Logger                         // Structured logger, replaces Logger
+	tracer               Tracer                               // Receives callbacks around driver operations
+	metrics              MetricsCollector                     // Receives metrics of the connections
+	fetchWarnings        bool                                 // Read the warnings with SHOW WARNINGS if there are any
+	warningsAsErrors     bool                                 // Return warnings as *MySQLWarnings errors
+	ignoredWarnings      []uint16                             // Warning codes which are not returned as errors
+	attachQueryToErrors  bool                                 // Set MySQLError.Query to the failing statement
+	retryPolicy          *RetryPolicy                         // Retries of auto-commit statements, nil disables retries
 }
 
 // Functional Options Pattern
@@ -125,6 +145,250 @@ func BeforeConnect(fn func(context.Context, *Config) error) Option {
 	}
 }
 
+// UsePasswordProvider sets the provider of the password for new connections,
+// which replaces Passwd. The password is cached until it expires. If the
+// server rejects a cached password with an access denied error, a new
+// password is requested from the provider and the connection is retried
+// once. Concurrent connections share a single request to the provider.
+//
+// Passwords from the provider may be sent with mysql_clear_password without
+// AllowCleartextPasswords if the connection uses TLS or a unix socket.
+func UsePasswordProvider(p PasswordProvider) Option {
+	return func(cfg *Config) error {
+		cfg.passwords = nil
+		if p != nil {
+			cfg.passwords = &passwordCache{provider: p}
+		}
+		return nil
+	}
+}
+
+// EnableCompression sets whether the compressed protocol is used if the
+// server supports it.
+func EnableCompression(yes bool) Option {
+	return func(cfg *Config) error {
+		cfg.Compress = yes
+		return nil
+	}
+}
+
+// CompressionAlgorithm sets the algorithm used by the compressed protocol.
+// Valid values are "zlib" (default) and "zstd". zstd requires a Compressor
+// registered with RegisterCompressor and MySQL 8.0.18+.
+func CompressionAlgorithm(algorithm string) Option {
+	return func(cfg *Config) error {
+		if algorithm != compressionZlib && algorithm != compressionZstd {
+			return errors.New("invalid compression algorithm: " + algorithm)
+		}
+		cfg.compressionAlgorithm = algorithm
+		return nil
+	}
+}
+
+// CompressionLevel sets the compression level used by the compressed
+// protocol. 0 selects the default level of the algorithm.
+func CompressionLevel(level int) Option {
+	return func(cfg *Config) error {
+		cfg.compressionLevel = level
+		return nil
+	}
+}
+
+// CompressionThreshold sets the minimum payload size in bytes that is sent
+// compressed. Smaller payloads are sent uncompressed. 0 selects the default
+// of 50 bytes.
+func CompressionThreshold(size int) Option {
+	return func(cfg *Config) error {
+		if size < 0 {
+			return errors.New("invalid compression threshold: " + strconv.Itoa(size))
+		}
+		cfg.compressionThreshold = size
+		return nil
+	}
+}
+
+// CursorFetchSize enables read-only server-side cursors for queries of
+// prepared statements which return rows. Instead of streaming the whole
+// result set, the rows are fetched in batches of the given size with
+// COM_STMT_FETCH. This bounds the memory used by the server for huge
+// result sets and allows other statements to run on the same connection
+// while the rows are being read.
+//
+// 0 (default) disables cursors.
+func CursorFetchSize(n int) Option {
+	return func(cfg *Config) error {
+		if n < 0 {
+			return errors.New("invalid cursor fetch size: " + strconv.Itoa(n))
+		}
+		cfg.cursorFetchSize = n
+		return nil
+	}
+}
+
+// ResetSessionOnReuse resets the session state on the server before a
+// connection from the pool is reused, so that user variables, temporary
+// tables, session variables and prepared statements do not leak from one
+// user of the connection to the next.
+//
+// The session is reset with COM_RESET_CONNECTION (MySQL 5.7.3+, MariaDB
+// 10.2.4+) or COM_CHANGE_USER on older servers. Afterwards the charset,
+// collation and system variables of the DSN are applied again.
+func ResetSessionOnReuse(yes bool) Option {
+	return func(cfg *Config) error {
+		cfg.resetSessionOnReuse = yes
+		return nil
+	}
+}
+
+// HostSelection sets the strategy used to choose the host of a new
+// connection if Addr lists several hosts separated by commas. Valid values
+// are "sequential" (default), which tries the hosts in the listed order,
+// "random", which tries them in a random order, and "roundrobin", which
+// starts each connection attempt at the host after the one the previous
+// attempt started at. The next host is tried if a host can not be reached
+// or does not match TargetServerType. Hosts resolved from SRV records are
+// always ordered by their priority and weight.
+func HostSelection(strategy string) Option {
+	return func(cfg *Config) error {
+		switch strategy {
+		case hostSelectionSequential, hostSelectionRandom, hostSelectionRoundRobin:
+		default:
+			return errors.New("invalid host selection strategy: " + strategy)
+		}
+		cfg.hostSelection = strategy
+		return nil
+	}
+}
+
+// TargetServerType sets the type of server a new connection must be made
+// to. Valid values are "any" (default), "primary" and "replica". After the
+// handshake, a server is considered a replica if @@read_only or
+// @@innodb_read_only is enabled. Connections to servers of another type are
+// closed and the next host is tried.
+func TargetServerType(serverType string) Option {
+	return func(cfg *Config) error {
+		switch serverType {
+		case serverTypeAny, serverTypePrimary, serverTypeReplica:
+		default:
+			return errors.New("invalid target server type: " + serverType)
+		}
+		cfg.targetServerType = serverType
+		return nil
+	}
+}
+
+// UseSRVResolver sets the resolver used to look up the SRV records of
+// addresses of the "srv" network. The default is net.DefaultResolver.
+func UseSRVResolver(r SRVResolver) Option {
+	return func(cfg *Config) error {
+		cfg.srvResolver = r
+		return nil
+	}
+}
+
+// SRVTTL sets how long the SRV records of addresses of the "srv" network
+// are cached before they are resolved again. 0 selects the default of one
+// minute.
+func SRVTTL(ttl time.Duration) Option {
+	return func(cfg *Config) error {
+		if ttl < 0 {
+			return errors.New("invalid SRV TTL: " + ttl.String())
+		}
+		cfg.srvTTL = ttl
+		return nil
+	}
+}
+
+// SlogLogger sets a structured logger which is used instead of Logger.
+// Messages are logged with levels, and with the connection id, server
+// address, auth plugin, packet sequence and MySQL error number as
+// attributes where they are known. Unlike Logger, which only receives
+// critical errors, the logger also receives debug and info messages, e.g.
+// for established connections and failed authentication.
+func SlogLogger(l *slog.Logger) Option {
+	return func(cfg *Config) error {
+		cfg.slogger = l
+		return nil
+	}
+}
+
+// UseTracer sets a Tracer which receives callbacks around connecting,
+// queries and prepared statements, e.g. to create OpenTelemetry spans.
+func UseTracer(t Tracer) Option {
+	return func(cfg *Config) error {
+		cfg.tracer = t
+		return nil
+	}
+}
+
+// UseMetrics sets a MetricsCollector which receives the traffic, commands,
+// handshakes, server errors and discarded bad connections of the
+// connections. NewMemoryMetrics returns a collector keeping them in memory.
+func UseMetrics(m MetricsCollector) Option {
+	return func(cfg *Config) error {
+		cfg.metrics = m
+		return nil
+	}
+}
+
+// FetchWarnings reads the warnings of a statement with SHOW WARNINGS if the
+// server reports any. They are returned by the Warnings method of Result,
+// and of Rows once all rows have been read. This costs an extra round-trip
+// for each statement with warnings. The warning count is available without
+// this option.
+func FetchWarnings(yes bool) Option {
+	return func(cfg *Config) error {
+		cfg.fetchWarnings = yes
+		return nil
+	}
+}
+
+// WarningsAsErrors returns a *MySQLWarnings error instead of the result of
+// Exec, and from Next of Rows once all rows have been read, if the server
+// reports warnings for the statement. The warnings are read with SHOW
+// WARNINGS. Warnings with a code passed to IgnoreWarnings are not returned as
+// errors. This replaces the removed strict mode.
+func WarningsAsErrors(yes bool) Option {
+	return func(cfg *Config) error {
+		cfg.warningsAsErrors = yes
+		return nil
+	}
+}
+
+// IgnoreWarnings sets the codes of the warnings which are not returned as
+// errors by WarningsAsErrors, e.g. 1287 for the deprecation warnings.
+func IgnoreWarnings(codes ...uint16) Option {
+	return func(cfg *Config) error {
+		cfg.ignoredWarnings = append([]uint16(nil), codes...)
+		return nil
+	}
+}
+
+// AttachQueryToErrors sets the Query field of the *MySQLError returned by
+// the server to the failing statement. For prepared statements, this is the
+// statement with its placeholders. The query is not part of the error
+// message, so it does not end up in logs unless it is logged explicitly.
+func AttachQueryToErrors(yes bool) Option {
+	return func(cfg *Config) error {
+		cfg.attachQueryToErrors = yes
+		return nil
+	}
+}
+
+// UseRetryPolicy retries single statements which fail with a deadlock, a
+// lock wait timeout or another error selected by the policy. Statements are
+// only retried in auto-commit mode outside of transactions, and not if they
+// are sent as text queries with MultiStatements enabled.
+func UseRetryPolicy(p RetryPolicy) Option {
+	return func(cfg *Config) error {
+		if p.MaxAttempts < 0 {
+			return errors.New("invalid retry attempts: " + strconv.Itoa(p.MaxAttempts))
+		}
+		cfg.retryPolicy = &p
+		return nil
+	}
+}
+
 func (cfg *Config) Clone() *Config {
 	cp := *cfg
 	if cp.TLS != nil {
@@ -166,7 +430,11 @@ func (cfg *Config) normalize() error {
 			return errors.New("default addr for network '" + cfg.Net + "' unknown")
 		}
 	} else if cfg.Net == "tcp" {
-		cfg.Addr = ensureHavePort(cfg.Addr)
+		addrs := splitAddrs(cfg.Addr)
+		for i := range addrs {
+			addrs[i] = ensureHavePort(addrs[i])
+		}
+		cfg.Addr = strings.Join(addrs, ",")
 	}
 
 	if cfg.TLS == nil {
@@ -188,6 +456,7 @@ func (cfg *Config) normalize() error {
 		}
 	}
 
+	// The server name of multi-host addresses is set for each host on connect
 	if cfg.TLS != nil && cfg.TLS.ServerName == "" && !cfg.TLS.InsecureSkipVerify {
 		host, _, err := net.SplitHostPort(cfg.Addr)
 		if err == nil {
@@ -277,6 +546,10 @@ func (cfg *Config) FormatDSN() string {
 		writeDSNParam(&buf, &hasParam, "allowOldPasswords", "true")
 	}
 
+	if cfg.attachQueryToErrors {
+		writeDSNParam(&buf, &hasParam, "attachQueryToErrors", "true")
+	}
+
 	if !cfg.CheckConnLiveness {
 		writeDSNParam(&buf, &hasParam, "checkConnLiveness", "false")
 	}
@@ -297,6 +570,42 @@ func (cfg *Config) FormatDSN() string {
 		writeDSNParam(&buf, &hasParam, "columnsWithAlias", "true")
 	}
 
+	if cfg.Compress {
+		writeDSNParam(&buf, &hasParam, "compress", "true")
+	}
+
+	if cfg.compressionAlgorithm != "" {
+		writeDSNParam(&buf, &hasParam, "compressionAlgorithm", cfg.compressionAlgorithm)
+	}
+
+	if cfg.compressionLevel != 0 {
+		writeDSNParam(&buf, &hasParam, "compressionLevel", strconv.Itoa(cfg.compressionLevel))
+	}
+
+	if cfg.compressionThreshold != 0 {
+		writeDSNParam(&buf, &hasParam, "compressionThreshold", strconv.Itoa(cfg.compressionThreshold))
+	}
+
+	if cfg.cursorFetchSize > 0 {
+		writeDSNParam(&buf, &hasParam, "cursorFetchSize", strconv.Itoa(cfg.cursorFetchSize))
+	}
+
+	if cfg.fetchWarnings {
+		writeDSNParam(&buf, &hasParam, "fetchWarnings", "true")
+	}
+
+	if cfg.hostSelection != "" {
+		writeDSNParam(&buf, &hasParam, "hostSelection", cfg.hostSelection)
+	}
+
+	if len(cfg.ignoredWarnings) > 0 {
+		codes := make([]string, len(cfg.ignoredWarnings))
+		for i, code := range cfg.ignoredWarnings {
+			codes[i] = strconv.Itoa(int(code))
+		}
+		writeDSNParam(&buf, &hasParam, "ignoreWarnings", strings.Join(codes, ","))
+	}
+
 	if cfg.InterpolateParams {
 		writeDSNParam(&buf, &hasParam, "interpolateParams", "true")
 	}
@@ -325,10 +634,22 @@ func (cfg *Config) FormatDSN() string {
 		writeDSNParam(&buf, &hasParam, "rejectReadOnly", "true")
 	}
 
+	if cfg.resetSessionOnReuse {
+		writeDSNParam(&buf, &hasParam, "resetSessionOnReuse", "true")
+	}
+
 	if len(cfg.ServerPubKey) > 0 {
 		writeDSNParam(&buf, &hasParam, "serverPubKey", url.QueryEscape(cfg.ServerPubKey))
 	}
 
+	if cfg.srvTTL > 0 {
+		writeDSNParam(&buf, &hasParam, "srvTTL", cfg.srvTTL.String())
+	}
+
+	if cfg.targetServerType != "" {
+		writeDSNParam(&buf, &hasParam, "targetServerType", cfg.targetServerType)
+	}
+
 	if cfg.Timeout > 0 {
 		writeDSNParam(&buf, &hasParam, "timeout", cfg.Timeout.String())
 	}
@@ -337,6 +658,10 @@ func (cfg *Config) FormatDSN() string {
 		writeDSNParam(&buf, &hasParam, "tls", url.QueryEscape(cfg.TLSConfig))
 	}
 
+	if cfg.warningsAsErrors {
+		writeDSNParam(&buf, &hasParam, "warningsAsErrors", "true")
+	}
+
 	if cfg.WriteTimeout > 0 {
 		writeDSNParam(&buf, &hasParam, "writeTimeout", cfg.WriteTimeout.String())
 	}
@@ -492,6 +817,14 @@ func parseDSNParams(cfg *Config, params string) (err error) {
 				return errors.New("invalid bool value: " + value)
 			}
 
+		// Attach the failing statement to server errors
+		case "attachQueryToErrors":
+			var isBool bool
+			cfg.attachQueryToErrors, isBool = readBool(value)
+			if !isBool {
+				return errors.New("invalid bool value: " + value)
+			}
+
 		// Check connections for Liveness before using them
 		case "checkConnLiveness":
 			var isBool bool
@@ -525,7 +858,69 @@ func parseDSNParams(cfg *Config, params string) (err error) {
 
 		// Compression
 		case "compress":
-			return errors.New("compression not implemented yet")
+			var isBool bool
+			cfg.Compress, isBool = readBool(value)
+			if !isBool {
+				return errors.New("invalid bool value: " + value)
+			}
+
+		case "compressionAlgorithm":
+			if err = CompressionAlgorithm(value)(cfg); err != nil {
+				return
+			}
+
+		case "compressionLevel":
+			cfg.compressionLevel, err = strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("invalid compressionLevel value: %v, error: %w", value, err)
+			}
+
+		case "compressionThreshold":
+			var size int
+			if size, err = strconv.Atoi(value); err != nil {
+				return fmt.Errorf("invalid compressionThreshold value: %v, error: %w", value, err)
+			}
+			if err = CompressionThreshold(size)(cfg); err != nil {
+				return
+			}
+
+		// Server-side cursors for prepared statements
+		case "cursorFetchSize":
+			var n int
+			if n, err = strconv.Atoi(value); err != nil {
+				return fmt.Errorf("invalid cursorFetchSize value: %v, error: %w", value, err)
+			}
+			if err = CursorFetchSize(n)(cfg); err != nil {
+				return
+			}
+
+		// Read warnings with SHOW WARNINGS
+		case "fetchWarnings":
+			var isBool bool
+			cfg.fetchWarnings, isBool = readBool(value)
+			if !isBool {
+				return errors.New("invalid bool value: " + value)
+			}
+
+		// Order in which the hosts of a multi-host address are tried
+		case "hostSelection":
+			if err = HostSelection(value)(cfg); err != nil {
+				return
+			}
+
+		// Warning codes which are not returned as errors
+		case "ignoreWarnings":
+			var codes []uint16
+			for _, v := range strings.Split(value, ",") {
+				code, err := strconv.ParseUint(v, 10, 16)
+				if err != nil {
+					return fmt.Errorf("invalid ignoreWarnings value: %v, error: %w", value, err)
+				}
+				codes = append(codes, uint16(code))
+			}
+			if err = IgnoreWarnings(codes...)(cfg); err != nil {
+				return
+			}
 
 		// Enable client side placeholder substitution
 		case "interpolateParams":
@@ -583,6 +978,14 @@ func parseDSNParams(cfg *Config, params string) (err error) {
 				return errors.New("invalid bool value: " + value)
 			}
 
+		// Reset the session state before a pooled connection is reused
+		case "resetSessionOnReuse":
+			var isBool bool
+			cfg.resetSessionOnReuse, isBool = readBool(value)
+			if !isBool {
+				return errors.New("invalid bool value: " + value)
+			}
+
 		// Server public key
 		case "serverPubKey":
 			name, err := url.QueryUnescape(value)
@@ -591,9 +994,25 @@ func parseDSNParams(cfg *Config, params string) (err error) {
 			}
 			cfg.ServerPubKey = name
 
+		// Cache duration of SRV records
+		case "srvTTL":
+			var ttl time.Duration
+			if ttl, err = time.ParseDuration(value); err != nil {
+				return
+			}
+			if err = SRVTTL(ttl)(cfg); err != nil {
+				return
+			}
+
 		// Strict mode
 		case "strict":
-			panic("strict mode has been removed. See https://github.com/go-sql-driver/mysql/wiki/strict-mode")
+			panic("strict mode has been removed, use warningsAsErrors instead. See https://github.com/go-sql-driver/mysql/wiki/strict-mode")
+
+		// Type of server to connect to
+		case "targetServerType":
+			if err = TargetServerType(value)(cfg); err != nil {
+				return
+			}
 
 		// Dial Timeout
 		case "timeout":
@@ -621,6 +1040,14 @@ func parseDSNParams(cfg *Config, params string) (err error) {
 				cfg.TLSConfig = name
 			}
 
+		// Return warnings as errors
+		case "warningsAsErrors":
+			var isBool bool
+			cfg.warningsAsErrors, isBool = readBool(value)
+			if !isBool {
+				return errors.New("invalid bool value: " + value)
+			}
+
 		// I/O write Timeout
 		case "writeTimeout":
 			cfg.WriteTimeout, err = time.ParseDuration(value)
@@ -77,6 +77,30 @@ var testDSNs = []struct {
 }, {
 	"user:password@/dbname?loc=UTC&timeout=30s&parseTime=true&timeTruncate=1h",
 	&Config{User: "user", Passwd: "password", Net: "tcp", Addr: "127.0.0.1:3306", DBName: "dbname", Loc: time.UTC, Timeout: 30 * time.Second, ParseTime: true, MaxAllowedPacket: defaultMaxAllowedPacket, Logger: defaultLogger, AllowNativePasswords: true, CheckConnLiveness: true, timeTruncate: time.Hour},
+}, {
+	"user:password@/dbname?compress=true&compressionAlgorithm=zstd&compressionLevel=7&compressionThreshold=1024",
+	&Config{User: "user", Passwd: "password", Net: "tcp", Addr: "127.0.0.1:3306", DBName: "dbname", Loc: time.UTC, MaxAllowedPacket: defaultMaxAllowedPacket, Logger: defaultLogger, AllowNativePasswords: true, CheckConnLiveness: true, Compress: true, compressionAlgorithm: "zstd", compressionLevel: 7, compressionThreshold: 1024},
+}, {
+	"user:password@/dbname?cursorFetchSize=1000",
+	&Config{User: "user", Passwd: "password", Net: "tcp", Addr: "127.0.0.1:3306", DBName: "dbname", Loc: time.UTC, MaxAllowedPacket: defaultMaxAllowedPacket, Logger: defaultLogger, AllowNativePasswords: true, CheckConnLiveness: true, cursorFetchSize: 1000},
+}, {
+	"user:password@/dbname?fetchWarnings=true",
+	&Config{User: "user", Passwd: "password", Net: "tcp", Addr: "127.0.0.1:3306", DBName: "dbname", Loc: time.UTC, MaxAllowedPacket: defaultMaxAllowedPacket, Logger: defaultLogger, AllowNativePasswords: true, CheckConnLiveness: true, fetchWarnings: true},
+}, {
+	"user:password@/dbname?attachQueryToErrors=true",
+	&Config{User: "user", Passwd: "password", Net: "tcp", Addr: "127.0.0.1:3306", DBName: "dbname", Loc: time.UTC, MaxAllowedPacket: defaultMaxAllowedPacket, Logger: defaultLogger, AllowNativePasswords: true, CheckConnLiveness: true, attachQueryToErrors: true},
+}, {
+	"user:password@/dbname?ignoreWarnings=1287,1265&warningsAsErrors=true",
+	&Config{User: "user", Passwd: "password", Net: "tcp", Addr: "127.0.0.1:3306", DBName: "dbname", Loc: time.UTC, MaxAllowedPacket: defaultMaxAllowedPacket, Logger: defaultLogger, AllowNativePasswords: true, CheckConnLiveness: true, warningsAsErrors: true, ignoredWarnings: []uint16{1287, 1265}},
+}, {
+	"user:password@/dbname?resetSessionOnReuse=true",
+	&Config{User: "user", Passwd: "password", Net: "tcp", Addr: "127.0.0.1:3306", DBName: "dbname", Loc: time.UTC, MaxAllowedPacket: defaultMaxAllowedPacket, Logger: defaultLogger, AllowNativePasswords: true, CheckConnLiveness: true, resetSessionOnReuse: true},
+}, {
+	"user:password@tcp(db1,db2:3307)/dbname?hostSelection=roundrobin&targetServerType=primary",
+	&Config{User: "user", Passwd: "password", Net: "tcp", Addr: "db1:3306,db2:3307", DBName: "dbname", Loc: time.UTC, MaxAllowedPacket: defaultMaxAllowedPacket, Logger: defaultLogger, AllowNativePasswords: true, CheckConnLiveness: true, hostSelection: "roundrobin", targetServerType: "primary"},
+}, {
+	"user:password@srv(_mysql._tcp.example.com)/dbname?srvTTL=30s",
+	&Config{User: "user", Passwd: "password", Net: "srv", Addr: "_mysql._tcp.example.com", DBName: "dbname", Loc: time.UTC, MaxAllowedPacket: defaultMaxAllowedPacket, Logger: defaultLogger, AllowNativePasswords: true, CheckConnLiveness: true, srvTTL: 30 * time.Second},
 },
 }
 
@@ -110,6 +134,14 @@ func TestDSNParserInvalid(t *testing.T) {
 		"net()/",                                // unknown default addr
 		"user:pass@tcp(127.0.0.1:3306)/db/name", // invalid dbname
 		"user:password@/dbname?allowFallbackToPlaintext=PREFERRED", // wrong bool flag
+		"user:password@/dbname?compressionAlgorithm=lz4",           // unknown compression algorithm
+		"user:password@/dbname?compressionThreshold=-1",            // negative threshold
+		"user:password@/dbname?cursorFetchSize=-1",                 // negative fetch size
+		"user:password@/dbname?ignoreWarnings=1287,x",              // invalid warning code
+		"user:password@/dbname?hostSelection=nearest",              // unknown host selection strategy
+		"user:password@/dbname?targetServerType=master",            // unknown server type
+		"user:password@/dbname?srvTTL=-1s",                         // negative SRV TTL
+		"user:password@srv()/dbname",                               // no SRV name
 		//"/dbname?arg=/some/unescaped/path",
 	}
 
@@ -0,0 +1,137 @@
+// Go MySQL Driver - A MySQL-Driver for Go's database/sql package
+//
+// Copyright 2024 The Go-MySQL-Driver Authors. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package mysql
+
+import (
+	"database/sql/driver"
+	"errors"
+	"io"
+	"net"
+	"strings"
+)
+
+// errorClass classifies server errors for the Is* functions.
+type errorClass uint8
+
+const (
+	classDeadlock errorClass = 1 << iota
+	classLockWaitTimeout
+	classDuplicateKey
+	classRetryable
+	classConnection
+	classReadOnly
+)
+
+//go:generate go run gen_errnames.go -o errnames.go errdata/mysqld_error.h errdata/extra_error.h
+
+// errorClasses are the classes of the server errors known to the driver, by
+// number.
+// https://dev.mysql.com/doc/mysql-errors/8.0/en/server-error-reference.html
+var errorClasses = map[uint16]errorClass{
+	1022: classDuplicateKey,                     // ER_DUP_KEY
+	1040: classConnection,                       // ER_CON_COUNT_ERROR
+	1053: classConnection,                       // ER_SERVER_SHUTDOWN
+	1062: classDuplicateKey,                     // ER_DUP_ENTRY
+	1077: classConnection,                       // ER_NORMAL_SHUTDOWN
+	1078: classConnection,                       // ER_GOT_SIGNAL
+	1079: classConnection,                       // ER_SHUTDOWN_COMPLETE
+	1080: classConnection,                       // ER_FORCING_CLOSE
+	1152: classConnection,                       // ER_ABORTING_CONNECTION
+	1153: classConnection,                       // ER_NET_PACKET_TOO_LARGE
+	1154: classConnection,                       // ER_NET_READ_ERROR_FROM_PIPE
+	1155: classConnection,                       // ER_NET_FCNTL_ERROR
+	1156: classConnection,                       // ER_NET_PACKETS_OUT_OF_ORDER
+	1157: classConnection,                       // ER_NET_UNCOMPRESS_ERROR
+	1158: classConnection,                       // ER_NET_READ_ERROR
+	1159: classConnection,                       // ER_NET_READ_INTERRUPTED
+	1160: classConnection,                       // ER_NET_ERROR_ON_WRITE
+	1161: classConnection,                       // ER_NET_WRITE_INTERRUPTED
+	1184: classConnection,                       // ER_NEW_ABORTING_CONNECTION
+	1203: classConnection,                       // ER_TOO_MANY_USER_CONNECTIONS
+	1205: classLockWaitTimeout | classRetryable, // ER_LOCK_WAIT_TIMEOUT
+	1213: classDeadlock | classRetryable,        // ER_LOCK_DEADLOCK
+	1290: classReadOnly,                         // ER_OPTION_PREVENTS_STATEMENT
+	1586: classDuplicateKey,                     // ER_DUP_ENTRY_WITH_KEY_NAME
+	1613: classRetryable,                        // ER_XA_RBTIMEOUT
+	1614: classDeadlock | classRetryable,        // ER_XA_RBDEADLOCK
+	1637: classRetryable,                        // ER_TOO_MANY_CONCURRENT_TRXS
+	1792: classReadOnly,                         // ER_CANT_EXECUTE_IN_READ_ONLY_TRANSACTION
+	1836: classReadOnly,                         // ER_READ_ONLY_MODE
+	1859: classDuplicateKey,                     // ER_DUP_UNKNOWN_IN_INDEX
+	1874: classReadOnly,                         // ER_INNODB_READ_ONLY
+	1927: classConnection,                       // ER_CONNECTION_KILLED (MariaDB)
+	3101: classRetryable,                        // ER_TRANSACTION_ROLLBACK_DURING_COMMIT
+	3169: classConnection,                       // ER_SESSION_WAS_KILLED
+	4031: classConnection,                       // ER_CLIENT_INTERACTION_TIMEOUT
+}
+
+// Name returns the name of the error in the server's source, e.g.
+// "ER_LOCK_DEADLOCK", or "" if the error is unknown to the driver.
+func (me *MySQLError) Name() string {
+	return serverErrorNames[me.Number]
+}
+
+func (me *MySQLError) class() errorClass {
+	class := errorClasses[me.Number]
+	// ER_OPTION_PREVENTS_STATEMENT is also returned for other options,
+	// e.g. --secure-file-priv.
+	if me.Number == 1290 && !strings.Contains(me.Message, "read-only") {
+		class &^= classReadOnly
+	}
+	return class
+}
+
+func hasErrorClass(err error, class errorClass) bool {
+	var me *MySQLError
+	return errors.As(err, &me) && me.class()&class != 0
+}
+
+// IsDeadlock reports whether err is a *MySQLError for a deadlock. The
+// transaction has been rolled back.
+func IsDeadlock(err error) bool {
+	return hasErrorClass(err, classDeadlock)
+}
+
+// IsLockWaitTimeout reports whether err is a *MySQLError for a lock wait
+// timeout. Only the statement has been rolled back, unless
+// innodb_rollback_on_timeout is enabled.
+func IsLockWaitTimeout(err error) bool {
+	return hasErrorClass(err, classLockWaitTimeout)
+}
+
+// IsDuplicateKey reports whether err is a *MySQLError for a duplicate entry
+// of a unique key. Use MySQLError.DuplicateEntry to get the key and value.
+func IsDuplicateKey(err error) bool {
+	return hasErrorClass(err, classDuplicateKey)
+}
+
+// IsRetryable reports whether err is a *MySQLError after which the
+// transaction may succeed if it is retried, e.g. a deadlock or a lock wait
+// timeout.
+func IsRetryable(err error) bool {
+	return hasErrorClass(err, classRetryable)
+}
+
+// IsReadOnly reports whether err is a *MySQLError because the server or
+// the transaction is read-only, e.g. after a failover.
+func IsReadOnly(err error) bool {
+	return hasErrorClass(err, classReadOnly)
+}
+
+// IsConnectionError reports whether err is caused by a broken connection:
+// a network error, ErrInvalidConn, driver.ErrBadConn or a *MySQLError
+// because the server closed or killed the connection.
+func IsConnectionError(err error) bool {
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, ErrInvalidConn) ||
+		errors.Is(err, errBadConnNoWrite) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) || hasErrorClass(err, classConnection)
+}
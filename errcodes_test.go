@@ -0,0 +1,131 @@
+// Go MySQL Driver - A MySQL-Driver for Go's database/sql package
+//
+// Copyright 2024 The Go-MySQL-Driver Authors. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package mysql
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"net"
+	"testing"
+)
+
+func TestErrorClassification(t *testing.T) {
+	wrap := func(err error) error { return fmt.Errorf("insert user: %w", err) }
+	for _, tc := range []struct {
+		err                                                            error
+		deadlock, lockWait, duplicate, retryable, connection, readOnly bool
+	}{
+		{err: &MySQLError{Number: 1213}, deadlock: true, retryable: true},
+		{err: wrap(&MySQLError{Number: 1205}), lockWait: true, retryable: true},
+		{err: &MySQLError{Number: 1062}, duplicate: true},
+		{err: &MySQLError{Number: 1586}, duplicate: true},
+		{err: &MySQLError{Number: 1792}, readOnly: true},
+		{err: &MySQLError{Number: 1290, Message: "The MySQL server is running with the --read-only option so it cannot execute this statement"}, readOnly: true},
+		{err: &MySQLError{Number: 1290, Message: "The MySQL server is running with the --secure-file-priv option so it cannot execute this statement"}},
+		{err: &MySQLError{Number: 1053}, connection: true},
+		{err: &MySQLError{Number: 1064}},
+		{err: wrap(driver.ErrBadConn), connection: true},
+		{err: ErrInvalidConn, connection: true},
+		{err: &net.OpError{Op: "read", Err: fmt.Errorf("connection reset by peer")}, connection: true},
+		{err: nil},
+	} {
+		if got := IsDeadlock(tc.err); got != tc.deadlock {
+			t.Errorf("IsDeadlock(%v) = %v", tc.err, got)
+		}
+		if got := IsLockWaitTimeout(tc.err); got != tc.lockWait {
+			t.Errorf("IsLockWaitTimeout(%v) = %v", tc.err, got)
+		}
+		if got := IsDuplicateKey(tc.err); got != tc.duplicate {
+			t.Errorf("IsDuplicateKey(%v) = %v", tc.err, got)
+		}
+		if got := IsRetryable(tc.err); got != tc.retryable {
+			t.Errorf("IsRetryable(%v) = %v", tc.err, got)
+		}
+		if got := IsConnectionError(tc.err); got != tc.connection {
+			t.Errorf("IsConnectionError(%v) = %v", tc.err, got)
+		}
+		if got := IsReadOnly(tc.err); got != tc.readOnly {
+			t.Errorf("IsReadOnly(%v) = %v", tc.err, got)
+		}
+	}
+
+}
+
+func TestMySQLErrorName(t *testing.T) {
+	for number, want := range map[uint16]string{
+		1146: "ER_NO_SUCH_TABLE",
+		1213: "ER_LOCK_DEADLOCK",
+		1397: "ER_XAER_NOTA",
+		1613: "ER_XA_RBTIMEOUT",
+		4031: "ER_CLIENT_INTERACTION_TIMEOUT",
+		999:  "",
+	} {
+		if name := (&MySQLError{Number: number}).Name(); name != want {
+			t.Errorf("error %d: expected %q, got %q", number, want, name)
+		}
+	}
+	for number := range errorClasses {
+		if serverErrorNames[number] == "" {
+			t.Errorf("classified error %d has no name", number)
+		}
+	}
+}
+
+func TestDuplicateEntry(t *testing.T) {
+	for _, tc := range []struct {
+		err        *MySQLError
+		key, value string
+		ok         bool
+	}{
+		{&MySQLError{Number: 1062, Message: "Duplicate entry 'a@example.com' for key 'users.email'"}, "users.email", "a@example.com", true},
+		{&MySQLError{Number: 1062, Message: "Duplicate entry 'it' for key 's' for key 'PRIMARY'"}, "PRIMARY", "it' for key 's", true},
+		{&MySQLError{Number: 1586, Message: "Duplicate entry '1-2' for key 'uniq'"}, "uniq", "1-2", true},
+		{&MySQLError{Number: 1022, Message: "Can't write; duplicate key in table 't'"}, "", "", false},
+		{&MySQLError{Number: 1064, Message: "Duplicate entry 'x' for key 'y'"}, "", "", false},
+	} {
+		key, value, ok := tc.err.DuplicateEntry()
+		if key != tc.key || value != tc.value || ok != tc.ok {
+			t.Errorf("%q: got (%q, %q, %v), want (%q, %q, %v)", tc.err.Message, key, value, ok, tc.key, tc.value, tc.ok)
+		}
+	}
+}
+
+func TestAttachQueryToErrors(t *testing.T) {
+	conn, mc := newRWMockConn(0)
+	mc.cfg.attachQueryToErrors = true
+	dupEntry := append([]byte{iERR, 0x26, 0x04, '#', '2', '3', '0', '0', '0'}, "Duplicate entry '1' for key 'PRIMARY'"...)
+	conn.queuedReplies = [][]byte{
+		makePackets(1, dupEntry),
+		makePackets(1, []byte{iOK, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}), // prepare OK, statement 1
+		makePackets(1, dupEntry),
+		makePackets(1, dupEntry),
+	}
+
+	ctx := context.Background()
+	_, err := mc.ExecContext(ctx, "INSERT INTO t VALUES (1)", nil)
+	if me, ok := err.(*MySQLError); !ok || me.Query != "INSERT INTO t VALUES (1)" {
+		t.Errorf("expected the query to be attached, got %#v", err)
+	}
+
+	stmt, err := mc.PrepareContext(ctx, "INSERT INTO t VALUES (1)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = stmt.(*mysqlStmt).Exec(nil)
+	if me, ok := err.(*MySQLError); !ok || me.Query != "INSERT INTO t VALUES (1)" {
+		t.Errorf("expected the statement to be attached, got %#v", err)
+	}
+
+	mc.cfg.attachQueryToErrors = false
+	_, err = mc.ExecContext(ctx, "INSERT INTO t VALUES (1)", nil)
+	if me, ok := err.(*MySQLError); !ok || me.Query != "" {
+		t.Errorf("expected no query, got %#v", err)
+	}
+}
@@ -0,0 +1,9 @@
+/*
+  Server errors which are not in mysqld_error.h above: errors of newer
+  MySQL versions and of MariaDB which the driver classifies.
+*/
+
+#define ER_CONNECTION_KILLED 1927
+#define ER_STATEMENT_TIMEOUT 1969
+#define ER_LOCK_NOWAIT 3572
+#define ER_CLIENT_INTERACTION_TIMEOUT 4031
@@ -13,6 +13,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strings"
 )
 
 // Various errors the driver might return. Can change between driver versions.
@@ -65,6 +66,7 @@ type MySQLError struct {
 	Number   uint16
 	SQLState [5]byte
 	Message  string
+	Query    string // the failing statement, if AttachQueryToErrors is enabled
 }
 
 // Error implements the error interface
@@ -83,3 +85,39 @@ func (me *MySQLError) Is(err error) bool {
 	}
 	return false
 }
+
+// DuplicateEntry returns the key and the duplicated value of a duplicate
+// entry error. ok is false for other errors and if the message does not
+// include them. Since MySQL 8.0.19, the key is prefixed with the table name,
+// e.g. "users.email".
+func (me *MySQLError) DuplicateEntry() (key, value string, ok bool) {
+	// "Duplicate entry '%-.192s' for key '%-.192s'"
+	const prefix, sep = "Duplicate entry '", "' for key '"
+	if me.class()&classDuplicateKey == 0 || !strings.HasPrefix(me.Message, prefix) || !strings.HasSuffix(me.Message, "'") {
+		return "", "", false
+	}
+	// the value may contain the separator, the key name rarely does
+	i := strings.LastIndex(me.Message, sep)
+	if i < len(prefix) {
+		return "", "", false
+	}
+	return me.Message[i+len(sep) : len(me.Message)-1], me.Message[len(prefix):i], true
+}
+
+// MySQLWarnings is returned instead of the result of a statement which
+// caused warnings if WarningsAsErrors is enabled.
+type MySQLWarnings struct {
+	Warnings []Warning // the warnings which are not ignored
+	Count    uint16    // warning count reported by the server, including ignored warnings
+}
+
+func (mw *MySQLWarnings) Error() string {
+	if len(mw.Warnings) == 0 {
+		return fmt.Sprintf("%d warnings", mw.Count)
+	}
+	msgs := make([]string, len(mw.Warnings))
+	for i, w := range mw.Warnings {
+		msgs[i] = w.String()
+	}
+	return strings.Join(msgs, "; ")
+}
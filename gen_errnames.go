@@ -0,0 +1,101 @@
+// Go MySQL Driver - A MySQL-Driver for Go's database/sql package
+//
+// Copyright 2024 The Go-MySQL-Driver Authors. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at http://mozilla.org/MPL/2.0/.
+
+//go:build ignore
+
+// gen_errnames generates the table of server error names from the
+// #define lines of mysqld_error.h files.
+//
+//	go run gen_errnames.go -o errnames.go errdata/mysqld_error.h errdata/extra_error.h
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+var define = regexp.MustCompile(`^#define\s+((?:ER|WARN)_[A-Z0-9_]+)\s+(\d+)\s*$`)
+
+// aliases of other codes in mysqld_error.h
+var skip = map[string]bool{
+	"ER_ERROR_FIRST": true,
+	"ER_ERROR_LAST":  true,
+}
+
+func main() {
+	out := flag.String("o", "errnames.go", "output file")
+	flag.Parse()
+
+	names := make(map[uint16]string)
+	for _, path := range flag.Args() {
+		if err := readHeader(path, names); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if len(names) == 0 {
+		log.Fatal("no error codes found")
+	}
+
+	numbers := make([]int, 0, len(names))
+	for n := range names {
+		numbers = append(numbers, int(n))
+	}
+	sort.Ints(numbers)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by gen_errnames.go from %v; DO NOT EDIT.\n\n", flag.Args())
+	buf.WriteString("package mysql\n\n")
+	buf.WriteString("// serverErrorNames are the names of the server errors in mysqld_error.h,\n// by number.\n")
+	buf.WriteString("var serverErrorNames = map[uint16]string{\n")
+	for _, n := range numbers {
+		fmt.Fprintf(&buf, "\t%d: %q,\n", n, names[uint16(n)])
+	}
+	buf.WriteString("}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// readHeader adds the error codes defined in the header at path to names.
+func readHeader(path string, names map[uint16]string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		m := define.FindStringSubmatch(scanner.Text())
+		if m == nil || skip[m[1]] {
+			continue
+		}
+		n, err := strconv.ParseUint(m[2], 10, 16)
+		if err != nil {
+			return fmt.Errorf("%s:%d: %v", path, line, err)
+		}
+		if name, ok := names[uint16(n)]; ok && name != m[1] {
+			return fmt.Errorf("%s:%d: %s has the number %d of %s", path, line, m[1], n, name)
+		}
+		names[uint16(n)] = m[1]
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,81 @@
+// Go MySQL Driver - A MySQL-Driver for Go's database/sql package
+//
+// Copyright 2024 The Go-MySQL-Driver Authors. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package mysql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// logEvent logs msg for the connection. With a slog.Logger, the connection
+// id, server address, auth plugin and packet sequence are added to attrs.
+func (mc *mysqlConn) logEvent(level slog.Level, msg string, err error, attrs ...slog.Attr) {
+	if mc.cfg.slogger != nil {
+		attrs = append(attrs,
+			slog.Uint64("conn_id", uint64(mc.connectionID)),
+			slog.String("addr", mc.cfg.Addr),
+			slog.Int("seq", int(mc.sequence)),
+		)
+		if mc.authPlugin != "" {
+			attrs = append(attrs, slog.String("auth_plugin", mc.authPlugin))
+		}
+	}
+	mc.cfg.logEvent(2, level, msg, err, attrs...)
+}
+
+// logEvent logs msg with the slog.Logger of cfg if one is set, adding err
+// and its error number to attrs. Otherwise messages of level Warn and above
+// are printed to cfg.Logger, prefixed with the file and line of the caller
+// skip frames up the stack.
+func (cfg *Config) logEvent(skip int, level slog.Level, msg string, err error, attrs ...slog.Attr) {
+	ctx := context.Background()
+	if l := cfg.slogger; l != nil {
+		if !l.Enabled(ctx, level) {
+			return
+		}
+		if err != nil {
+			attrs = append(attrs, slog.String("error", err.Error()))
+			var mysqlErr *MySQLError
+			if errors.As(err, &mysqlErr) {
+				attrs = append(attrs, slog.Int("error_number", int(mysqlErr.Number)))
+			}
+		}
+		var pcs [1]uintptr
+		runtime.Callers(skip+1, pcs[:]) // skip runtime.Callers
+		r := slog.NewRecord(time.Now(), level, msg, pcs[0])
+		r.AddAttrs(attrs...)
+		_ = l.Handler().Handle(ctx, r)
+		return
+	}
+
+	// Logger is used to log critical error messages only
+	if level < slog.LevelWarn {
+		return
+	}
+	var v []any
+	if _, filename, lineno, ok := runtime.Caller(skip); ok {
+		if pos := strings.LastIndexByte(filename, '/'); pos != -1 {
+			filename = filename[pos+1:]
+		}
+		v = append(v, fmt.Sprintf("%s:%d ", filename, lineno))
+	}
+	v = append(v, msg)
+	if err != nil {
+		v = append(v, ": ", err.Error())
+	}
+	for _, attr := range attrs {
+		v = append(v, " ", attr.String())
+	}
+	cfg.Logger.Print(v...)
+}
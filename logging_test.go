@@ -0,0 +1,146 @@
+// Go MySQL Driver - A MySQL-Driver for Go's database/sql package
+//
+// Copyright 2024 The Go-MySQL-Driver Authors. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package mysql
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"log/slog"
+	"net"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// recordHandler collects the records and their attributes.
+type recordHandler struct {
+	mu      sync.Mutex
+	records []slog.Record
+}
+
+func (h *recordHandler) Enabled(context.Context, slog.Level) bool { return true }
+func (h *recordHandler) WithAttrs([]slog.Attr) slog.Handler       { return h }
+func (h *recordHandler) WithGroup(string) slog.Handler            { return h }
+
+func (h *recordHandler) Handle(_ context.Context, r slog.Record) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.records = append(h.records, r)
+	return nil
+}
+
+func (h *recordHandler) find(msg string) (map[string]string, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, r := range h.records {
+		if r.Message == msg {
+			attrs := make(map[string]string)
+			r.Attrs(func(a slog.Attr) bool {
+				attrs[a.Key] = a.Value.String()
+				return true
+			})
+			return attrs, true
+		}
+	}
+	return nil, false
+}
+
+func TestLogEventSlog(t *testing.T) {
+	handler := new(recordHandler)
+	cfg := NewConfig()
+	cfg.Addr = "db1:3306"
+	if err := cfg.Apply(SlogLogger(slog.New(handler))); err != nil {
+		t.Fatal(err)
+	}
+	mc := &mysqlConn{cfg: cfg, connectionID: 42, authPlugin: "caching_sha2_password", sequence: 3}
+
+	mc.logEvent(slog.LevelDebug, "query failed", &MySQLError{Number: 1213, Message: "Deadlock found"})
+	_, file, _, _ := runtime.Caller(0)
+
+	if len(handler.records) != 1 {
+		t.Fatalf("expected 1 record, got %d", len(handler.records))
+	}
+	r := handler.records[0]
+	if r.Level != slog.LevelDebug {
+		t.Errorf("expected level DEBUG, got %v", r.Level)
+	}
+	frame, _ := runtime.CallersFrames([]uintptr{r.PC}).Next()
+	if frame.File != file {
+		t.Errorf("expected the source %s, got %s", file, frame.File)
+	}
+
+	attrs, _ := handler.find("query failed")
+	for key, want := range map[string]string{
+		"conn_id":      "42",
+		"addr":         "db1:3306",
+		"seq":          "3",
+		"auth_plugin":  "caching_sha2_password",
+		"error":        "Error 1213: Deadlock found",
+		"error_number": "1213",
+	} {
+		if attrs[key] != want {
+			t.Errorf("attribute %s: got %q, want %q", key, attrs[key], want)
+		}
+	}
+}
+
+func TestLogEventLogger(t *testing.T) {
+	var buf bytes.Buffer
+	cfg := NewConfig()
+	cfg.Logger = log.New(&buf, "", 0)
+	mc := &mysqlConn{cfg: cfg}
+
+	mc.logEvent(slog.LevelInfo, "connected", nil)
+	if buf.Len() != 0 {
+		t.Fatalf("expected no output below level WARN, got %q", buf.String())
+	}
+
+	mc.logEvent(slog.LevelWarn, "unknown auth plugin", ErrUnknownPlugin, slog.String("plugin", "foo"))
+	want := "unknown auth plugin: " + ErrUnknownPlugin.Error() + " plugin=foo\n"
+	if got := buf.String(); !strings.HasPrefix(got, "logging_test.go:") || !strings.HasSuffix(got, want) {
+		t.Errorf("got %q, want logging_test.go:<line> %q", got, want)
+	}
+}
+
+func TestConnectorSlog(t *testing.T) {
+	handler := new(recordHandler)
+	cfg, err := ParseDSN("root@tcp(db1)/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.DialFunc = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		client, server := net.Pipe()
+		go fakeAuthServer(server, "secret")
+		return client, nil
+	}
+	if err := cfg.Apply(SlogLogger(slog.New(handler))); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := newConnector(cfg).Connect(context.Background()); err == nil {
+		t.Fatal("expected the wrong password to be rejected")
+	}
+
+	attrs, ok := handler.find("authentication failed")
+	if !ok {
+		t.Fatal("authentication failure was not logged")
+	}
+	for key, want := range map[string]string{
+		"conn_id":      "165",
+		"addr":         "db1:3306",
+		"auth_plugin":  "mysql_native_password",
+		"error_number": "1045",
+	} {
+		if attrs[key] != want {
+			t.Errorf("attribute %s: got %q, want %q", key, attrs[key], want)
+		}
+	}
+}
@@ -0,0 +1,261 @@
+// Go MySQL Driver - A MySQL-Driver for Go's database/sql package
+//
+// Copyright 2024 The Go-MySQL-Driver Authors. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package mysql
+
+import (
+	"crypto/tls"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// MetricsCollector receives metrics of the connections of a Config, e.g. to
+// export them to Prometheus. Implementations must be safe for concurrent use
+// by multiple connections. MemoryMetrics is an implementation which keeps
+// the metrics in memory.
+type MetricsCollector interface {
+	// PacketRead is called for each packet read from the server. Bytes is
+	// the size of the packet including its header as read from the network;
+	// with compression, it is called for each compressed packet.
+	PacketRead(bytes int)
+	// PacketWritten is called for each packet written to the server. Bytes
+	// is the size of the packet including its header as written to the
+	// network; with compression, it is called for each compressed packet.
+	PacketWritten(bytes int)
+	// CommandSent is called for each command sent to the server, with the
+	// name of the command, e.g. "COM_QUERY". Each command is a round-trip.
+	CommandSent(command string)
+	// Connected is called after a connection has been established.
+	Connected(info ConnectionMetrics)
+	// ServerError is called for each error returned by the server.
+	ServerError(number uint16)
+	// BadConnDiscarded is called when a connection is reported as bad to
+	// database/sql, which discards it.
+	BadConnDiscarded()
+}
+
+// ConnectionMetrics describes an established connection.
+type ConnectionMetrics struct {
+	HandshakeDuration time.Duration // from dialing to the end of authentication
+	AuthPlugin        string
+	TLSVersion        string // e.g. "TLS 1.3", empty without TLS
+}
+
+// commandNames are the names of the commands, indexed by their byte.
+var commandNames = [...]string{
+	0:                   "COM_SLEEP",
+	comQuit:             "COM_QUIT",
+	comInitDB:           "COM_INIT_DB",
+	comQuery:            "COM_QUERY",
+	comFieldList:        "COM_FIELD_LIST",
+	comCreateDB:         "COM_CREATE_DB",
+	comDropDB:           "COM_DROP_DB",
+	comRefresh:          "COM_REFRESH",
+	comShutdown:         "COM_SHUTDOWN",
+	comStatistics:       "COM_STATISTICS",
+	comProcessInfo:      "COM_PROCESS_INFO",
+	comConnect:          "COM_CONNECT",
+	comProcessKill:      "COM_PROCESS_KILL",
+	comDebug:            "COM_DEBUG",
+	comPing:             "COM_PING",
+	comTime:             "COM_TIME",
+	comDelayedInsert:    "COM_DELAYED_INSERT",
+	comChangeUser:       "COM_CHANGE_USER",
+	comBinlogDump:       "COM_BINLOG_DUMP",
+	comTableDump:        "COM_TABLE_DUMP",
+	comConnectOut:       "COM_CONNECT_OUT",
+	comRegisterSlave:    "COM_REGISTER_SLAVE",
+	comStmtPrepare:      "COM_STMT_PREPARE",
+	comStmtExecute:      "COM_STMT_EXECUTE",
+	comStmtSendLongData: "COM_STMT_SEND_LONG_DATA",
+	comStmtClose:        "COM_STMT_CLOSE",
+	comStmtReset:        "COM_STMT_RESET",
+	comSetOption:        "COM_SET_OPTION",
+	comStmtFetch:        "COM_STMT_FETCH",
+	comDaemon:           "COM_DAEMON",
+	comBinlogDumpGTID:   "COM_BINLOG_DUMP_GTID",
+	comResetConnection:  "COM_RESET_CONNECTION",
+}
+
+// commandName returns the name of the command cmd.
+func commandName(cmd byte) string {
+	if int(cmd) < len(commandNames) {
+		return commandNames[cmd]
+	}
+	return "COM_" + strconv.Itoa(int(cmd))
+}
+
+// collectConnected reports the established connection to the collector.
+// handshake is the time from dialing to the end of authentication.
+func (mc *mysqlConn) collectConnected(handshake time.Duration) {
+	m := mc.metrics
+	if m == nil {
+		return
+	}
+	info := ConnectionMetrics{
+		HandshakeDuration: handshake,
+		AuthPlugin:        mc.authPlugin,
+	}
+	if tc, ok := mc.netConn.(*tls.Conn); ok {
+		info.TLSVersion = tls.VersionName(tc.ConnectionState().Version)
+	}
+	m.Connected(info)
+}
+
+// collectBadConn reports a connection which is discarded as bad.
+func (mc *mysqlConn) collectBadConn() {
+	if m := mc.metrics; m != nil {
+		m.BadConnDiscarded()
+	}
+}
+
+// handshakeBuckets are the upper bounds of the buckets of the handshake
+// duration histogram of MemoryMetrics.
+var handshakeBuckets = []time.Duration{
+	time.Millisecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	5 * time.Second,
+}
+
+// MemoryMetrics is a MetricsCollector which keeps the metrics in memory.
+// Use Snapshot to read them.
+type MemoryMetrics struct {
+	bytesRead       atomic.Uint64
+	bytesWritten    atomic.Uint64
+	packetsRead     atomic.Uint64
+	packetsWritten  atomic.Uint64
+	badConnDiscards atomic.Uint64
+
+	mu sync.Mutex
+	m  MetricsSnapshot // maps and histogram, guarded by mu
+}
+
+// MetricsSnapshot holds the metrics of MemoryMetrics at one point in time.
+type MetricsSnapshot struct {
+	BytesRead       uint64
+	BytesWritten    uint64
+	PacketsRead     uint64
+	PacketsWritten  uint64
+	BadConnDiscards uint64
+
+	Commands     map[string]uint64 // by command name
+	ServerErrors map[uint16]uint64 // by MySQLError.Number
+	AuthPlugins  map[string]uint64 // connections by auth plugin
+	TLSVersions  map[string]uint64 // connections by TLS version, "" without TLS
+
+	// Histogram of the handshake durations. HandshakeCounts[i] is the
+	// number of handshakes which took at most HandshakeBuckets[i], and
+	// longer than the previous bucket. The last element counts the
+	// handshakes longer than all buckets.
+	HandshakeBuckets []time.Duration
+	HandshakeCounts  []uint64
+	HandshakeSum     time.Duration
+	Handshakes       uint64
+}
+
+// NewMemoryMetrics returns a MemoryMetrics without any recorded metrics.
+func NewMemoryMetrics() *MemoryMetrics {
+	return &MemoryMetrics{m: MetricsSnapshot{
+		Commands:        make(map[string]uint64),
+		ServerErrors:    make(map[uint16]uint64),
+		AuthPlugins:     make(map[string]uint64),
+		TLSVersions:     make(map[string]uint64),
+		HandshakeCounts: make([]uint64, len(handshakeBuckets)+1),
+	}}
+}
+
+// PacketRead implements MetricsCollector interface.
+func (m *MemoryMetrics) PacketRead(bytes int) {
+	m.packetsRead.Add(1)
+	m.bytesRead.Add(uint64(bytes))
+}
+
+// PacketWritten implements MetricsCollector interface.
+func (m *MemoryMetrics) PacketWritten(bytes int) {
+	m.packetsWritten.Add(1)
+	m.bytesWritten.Add(uint64(bytes))
+}
+
+// CommandSent implements MetricsCollector interface.
+func (m *MemoryMetrics) CommandSent(command string) {
+	m.mu.Lock()
+	m.m.Commands[command]++
+	m.mu.Unlock()
+}
+
+// Connected implements MetricsCollector interface.
+func (m *MemoryMetrics) Connected(info ConnectionMetrics) {
+	i := 0
+	for i < len(handshakeBuckets) && info.HandshakeDuration > handshakeBuckets[i] {
+		i++
+	}
+
+	m.mu.Lock()
+	m.m.HandshakeCounts[i]++
+	m.m.HandshakeSum += info.HandshakeDuration
+	m.m.Handshakes++
+	m.m.AuthPlugins[info.AuthPlugin]++
+	m.m.TLSVersions[info.TLSVersion]++
+	m.mu.Unlock()
+}
+
+// ServerError implements MetricsCollector interface.
+func (m *MemoryMetrics) ServerError(number uint16) {
+	m.mu.Lock()
+	m.m.ServerErrors[number]++
+	m.mu.Unlock()
+}
+
+// BadConnDiscarded implements MetricsCollector interface.
+func (m *MemoryMetrics) BadConnDiscarded() {
+	m.badConnDiscards.Add(1)
+}
+
+// Snapshot returns a copy of the current metrics.
+func (m *MemoryMetrics) Snapshot() MetricsSnapshot {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s := MetricsSnapshot{
+		BytesRead:        m.bytesRead.Load(),
+		BytesWritten:     m.bytesWritten.Load(),
+		PacketsRead:      m.packetsRead.Load(),
+		PacketsWritten:   m.packetsWritten.Load(),
+		BadConnDiscards:  m.badConnDiscards.Load(),
+		Commands:         make(map[string]uint64, len(m.m.Commands)),
+		ServerErrors:     make(map[uint16]uint64, len(m.m.ServerErrors)),
+		AuthPlugins:      make(map[string]uint64, len(m.m.AuthPlugins)),
+		TLSVersions:      make(map[string]uint64, len(m.m.TLSVersions)),
+		HandshakeBuckets: append([]time.Duration(nil), handshakeBuckets...),
+		HandshakeCounts:  append([]uint64(nil), m.m.HandshakeCounts...),
+		HandshakeSum:     m.m.HandshakeSum,
+		Handshakes:       m.m.Handshakes,
+	}
+	for k, v := range m.m.Commands {
+		s.Commands[k] = v
+	}
+	for k, v := range m.m.ServerErrors {
+		s.ServerErrors[k] = v
+	}
+	for k, v := range m.m.AuthPlugins {
+		s.AuthPlugins[k] = v
+	}
+	for k, v := range m.m.TLSVersions {
+		s.TLSVersions[k] = v
+	}
+	return s
+}
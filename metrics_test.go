@@ -0,0 +1,132 @@
+// Go MySQL Driver - A MySQL-Driver for Go's database/sql package
+//
+// Copyright 2024 The Go-MySQL-Driver Authors. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package mysql
+
+import (
+	"context"
+	"database/sql/driver"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestMetricsConnect(t *testing.T) {
+	metrics := NewMemoryMetrics()
+	cfg, err := ParseDSN("root@tcp(db1)/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.DialFunc = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		client, server := net.Pipe()
+		go fakeServer(server, "", map[string]string{"version": "8.0.36"})
+		return client, nil
+	}
+	if err := cfg.Apply(UseMetrics(metrics)); err != nil {
+		t.Fatal(err)
+	}
+
+	conn, err := newConnector(cfg).Connect(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if _, err := conn.(*mysqlConn).getSystemVar("version"); err != nil {
+		t.Fatal(err)
+	}
+
+	s := metrics.Snapshot()
+	if s.Handshakes != 1 || s.AuthPlugins["mysql_native_password"] != 1 || s.TLSVersions[""] != 1 {
+		t.Errorf("unexpected handshake metrics %+v", s)
+	}
+	// handshake, OK and the result set of the query
+	if s.PacketsRead != 7 || s.PacketsWritten != 2 {
+		t.Errorf("expected 7 packets read and 2 written, got %d and %d", s.PacketsRead, s.PacketsWritten)
+	}
+	if want := uint64(4 + len(handshakeV10) + 4 + 7); s.BytesRead < want {
+		t.Errorf("expected at least %d bytes read, got %d", want, s.BytesRead)
+	}
+	if len(s.Commands) != 1 || s.Commands["COM_QUERY"] != 1 {
+		t.Errorf("expected a single COM_QUERY, got %v", s.Commands)
+	}
+}
+
+func TestMetricsCompressed(t *testing.T) {
+	wmetrics := NewMemoryMetrics()
+	wconn, wmc := newCompressedRWMockConn(0)
+	wmc.metrics = wmetrics
+	if err := wmc.writePacket(makeCompressiblePacket(1000)); err != nil {
+		t.Fatal(err)
+	}
+	s := wmetrics.Snapshot()
+	if s.PacketsWritten != 1 || s.BytesWritten != uint64(len(wconn.written)) {
+		t.Errorf("expected 1 packet of %d bytes written, got %d packets of %d bytes", len(wconn.written), s.PacketsWritten, s.BytesWritten)
+	}
+	if s.BytesWritten >= 4+1000 {
+		t.Errorf("got %d bytes written, not the compressed size", s.BytesWritten)
+	}
+
+	rmetrics := NewMemoryMetrics()
+	rconn, rmc := newCompressedRWMockConn(0)
+	rmc.metrics = rmetrics
+	rconn.data = wconn.written
+	if _, err := rmc.readPacket(); err != nil {
+		t.Fatal(err)
+	}
+	s = rmetrics.Snapshot()
+	if s.PacketsRead != 1 || s.BytesRead != uint64(len(wconn.written)) {
+		t.Errorf("expected 1 packet of %d bytes read, got %d packets of %d bytes", len(wconn.written), s.PacketsRead, s.BytesRead)
+	}
+}
+
+func TestMetricsErrors(t *testing.T) {
+	metrics := NewMemoryMetrics()
+	conn, mc := newRWMockConn(0)
+	mc.metrics = metrics
+	conn.queuedReplies = [][]byte{
+		makePackets(1, append([]byte{iERR, 0xbd, 0x04, '#', '4', '0', '0', '0', '1'}, "Deadlock found"...)),
+	}
+
+	if _, err := mc.ExecContext(context.Background(), "UPDATE t SET a = 1", nil); err == nil {
+		t.Fatal("expected an error")
+	}
+	if err := mc.markBadConn(errBadConnNoWrite); err != driver.ErrBadConn {
+		t.Fatalf("expected ErrBadConn, got %v", err)
+	}
+
+	s := metrics.Snapshot()
+	if s.ServerErrors[1213] != 1 {
+		t.Errorf("expected error 1213 to be counted, got %v", s.ServerErrors)
+	}
+	if s.BadConnDiscards != 1 {
+		t.Errorf("expected 1 bad connection, got %d", s.BadConnDiscards)
+	}
+}
+
+func TestMetricsHandshakeHistogram(t *testing.T) {
+	metrics := NewMemoryMetrics()
+	metrics.Connected(ConnectionMetrics{HandshakeDuration: 3 * time.Millisecond, TLSVersion: "TLS 1.3"})
+	metrics.Connected(ConnectionMetrics{HandshakeDuration: time.Minute, TLSVersion: "TLS 1.3"})
+
+	s := metrics.Snapshot()
+	if len(s.HandshakeCounts) != len(s.HandshakeBuckets)+1 {
+		t.Fatalf("got %d counts for %d buckets", len(s.HandshakeCounts), len(s.HandshakeBuckets))
+	}
+	if s.HandshakeCounts[1] != 1 || s.HandshakeCounts[len(s.HandshakeCounts)-1] != 1 {
+		t.Errorf("unexpected histogram %v", s.HandshakeCounts)
+	}
+	if s.HandshakeSum != time.Minute+3*time.Millisecond || s.TLSVersions["TLS 1.3"] != 2 {
+		t.Errorf("unexpected metrics %+v", s)
+	}
+
+	// snapshots are copies
+	s.TLSVersions["TLS 1.3"] = 0
+	if metrics.Snapshot().TLSVersions["TLS 1.3"] != 2 {
+		t.Error("modifying a snapshot changed the metrics")
+	}
+}
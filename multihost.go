@@ -0,0 +1,104 @@
+// Go MySQL Driver - A MySQL-Driver for Go's database/sql package
+//
+// Copyright 2024 The Go-MySQL-Driver Authors. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package mysql
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"strings"
+)
+
+// Strategies for choosing the order in which the hosts of a multi-host
+// address are tried.
+const (
+	hostSelectionSequential = "sequential" // always in the listed order
+	hostSelectionRandom     = "random"     // in a random order
+	hostSelectionRoundRobin = "roundrobin" // starting at the host after the previous start
+)
+
+// Server types accepted by TargetServerType.
+const (
+	serverTypeAny     = "any"
+	serverTypePrimary = "primary"
+	serverTypeReplica = "replica"
+)
+
+var errServerType = errors.New("server type does not match targetServerType")
+
+// splitAddrs returns the hosts of a comma separated address list.
+func splitAddrs(addr string) []string {
+	return strings.Split(addr, ",")
+}
+
+// hostOrder returns the hosts of cfg.Addr in the order they should be tried.
+// The hosts of SRV addresses are resolved and ordered as RFC 2782 requires.
+func (c *connector) hostOrder(ctx context.Context, cfg *Config) ([]string, error) {
+	if cfg.Net == srvNet {
+		records, err := c.srv.lookup(ctx, cfg)
+		if err != nil {
+			return nil, err
+		}
+		// Records with the target "." mean that the service is not available.
+		addrs := srvAddrs(records)
+		if len(addrs) == 0 {
+			return nil, errNoSRVRecords
+		}
+		return addrs, nil
+	}
+
+	addrs := splitAddrs(cfg.Addr)
+	if len(addrs) == 1 {
+		return addrs, nil
+	}
+
+	switch cfg.hostSelection {
+	case hostSelectionRandom:
+		rand.Shuffle(len(addrs), func(i, j int) {
+			addrs[i], addrs[j] = addrs[j], addrs[i]
+		})
+	case hostSelectionRoundRobin:
+		start := int((c.nextHost.Add(1) - 1) % uint32(len(addrs)))
+		addrs = append(addrs[start:], addrs[:start]...)
+	}
+	return addrs, nil
+}
+
+// checkServerType returns errServerType if the server of mc is not of the
+// type requested by cfg.targetServerType.
+func (mc *mysqlConn) checkServerType() error {
+	want := mc.cfg.targetServerType
+	if want == "" || want == serverTypeAny {
+		return nil
+	}
+
+	readOnly, err := mc.readOnly()
+	if err != nil {
+		return err
+	}
+	if readOnly != (want == serverTypeReplica) {
+		return errServerType
+	}
+	return nil
+}
+
+// readOnly reports whether the server rejects writes, because either
+// @@read_only or @@innodb_read_only is enabled.
+func (mc *mysqlConn) readOnly() (bool, error) {
+	for _, name := range []string{"read_only", "innodb_read_only"} {
+		value, err := mc.getSystemVar(name)
+		if err != nil {
+			return false, err
+		}
+		if readOnly, _ := readBool(string(value)); readOnly {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,145 @@
+// Go MySQL Driver - A MySQL-Driver for Go's database/sql package
+//
+// Copyright 2024 The Go-MySQL-Driver Authors. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package mysql
+
+import (
+	"context"
+	"errors"
+	"net"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// newMultiHostConnector returns a connector for the hosts of dsn. Hosts
+// missing from vars can not be reached, the others are fake servers
+// answering system variable queries with their entry of vars.
+func newMultiHostConnector(t *testing.T, dsn string, vars map[string]map[string]string) (*connector, *[]string) {
+	t.Helper()
+	cfg, err := ParseDSN(dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var dialed []string
+	cfg.DialFunc = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		dialed = append(dialed, addr)
+		hostVars, ok := vars[addr]
+		if !ok {
+			return nil, errors.New("connection refused")
+		}
+		client, server := net.Pipe()
+		go fakeServer(server, "", hostVars)
+		return client, nil
+	}
+	return newConnector(cfg), &dialed
+}
+
+func TestConnectorMultiHostFailover(t *testing.T) {
+	connector, dialed := newMultiHostConnector(t, "root@tcp(db1,db2)/", map[string]map[string]string{
+		"db2:3306": {},
+	})
+
+	conn, err := connector.Connect(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	if addr := conn.(*mysqlConn).cfg.Addr; addr != "db2:3306" {
+		t.Errorf("connected to %q, want db2:3306", addr)
+	}
+	if want := []string{"db1:3306", "db2:3306"}; !reflect.DeepEqual(*dialed, want) {
+		t.Errorf("dialed %v, want %v", *dialed, want)
+	}
+}
+
+func TestConnectorMultiHostAllDown(t *testing.T) {
+	connector, _ := newMultiHostConnector(t, "root@tcp(db1,db2)/", nil)
+
+	_, err := connector.Connect(context.Background())
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	for _, host := range []string{"db1:3306", "db2:3306"} {
+		if !strings.Contains(err.Error(), host) {
+			t.Errorf("error %q does not mention %s", err, host)
+		}
+	}
+}
+
+func TestConnectorTargetServerType(t *testing.T) {
+	vars := map[string]map[string]string{
+		"replica1:3306": {"read_only": "1", "innodb_read_only": "0"},
+		"replica2:3306": {"read_only": "0", "innodb_read_only": "1"},
+		"primary:3306":  {"read_only": "0", "innodb_read_only": "0"},
+	}
+	tests := []struct {
+		serverType string
+		want       string
+	}{
+		{"primary", "primary:3306"},
+		{"replica", "replica1:3306"},
+		{"any", "replica1:3306"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.serverType, func(t *testing.T) {
+			connector, _ := newMultiHostConnector(t,
+				"root@tcp(replica1,replica2,primary)/?targetServerType="+test.serverType, vars)
+
+			conn, err := connector.Connect(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer conn.Close()
+
+			if addr := conn.(*mysqlConn).cfg.Addr; addr != test.want {
+				t.Errorf("connected to %q, want %q", addr, test.want)
+			}
+		})
+	}
+}
+
+func TestConnectorTargetServerTypeNoMatch(t *testing.T) {
+	connector, _ := newMultiHostConnector(t, "root@tcp(replica)/?targetServerType=primary", map[string]map[string]string{
+		"replica:3306": {"read_only": "1"},
+	})
+
+	_, err := connector.Connect(context.Background())
+	if !errors.Is(err, errServerType) {
+		t.Fatalf("expected errServerType, got %v", err)
+	}
+}
+
+func TestHostOrder(t *testing.T) {
+	cfg, err := ParseDSN("root@tcp(db1,db2,db3)/?hostSelection=roundrobin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := newConnector(cfg)
+
+	for _, want := range [][]string{
+		{"db1:3306", "db2:3306", "db3:3306"},
+		{"db2:3306", "db3:3306", "db1:3306"},
+		{"db3:3306", "db1:3306", "db2:3306"},
+		{"db1:3306", "db2:3306", "db3:3306"},
+	} {
+		if got, _ := c.hostOrder(context.Background(), cfg); !reflect.DeepEqual(got, want) {
+			t.Errorf("roundrobin: got %v, want %v", got, want)
+		}
+	}
+
+	cfg.hostSelection = hostSelectionRandom
+	got, _ := c.hostOrder(context.Background(), cfg)
+	sort.Strings(got)
+	if want := []string{"db1:3306", "db2:3306", "db3:3306"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("random: got %v, want a permutation of %v", got, want)
+	}
+}
@@ -16,6 +16,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"math"
 	"strconv"
 	"time"
@@ -36,7 +37,7 @@ func (mc *mysqlConn) readPacket() ([]byte, error) {
 			if cerr := mc.canceled.Value(); cerr != nil {
 				return nil, cerr
 			}
-			mc.log(err)
+			mc.logEvent(slog.LevelError, "reading packet header failed", err)
 			return nil, ErrInvalidConn
 		}
 
@@ -44,21 +45,30 @@ func (mc *mysqlConn) readPacket() ([]byte, error) {
 		pktLen := int(uint32(data[0]) | uint32(data[1])<<8 | uint32(data[2])<<16)
 
 		// check packet sync [8 bit]
-		if data[3] != mc.sequence {
-			mc.close()
-			if data[3] > mc.sequence {
-				return nil, ErrPktSyncMul
+		// The sequence of packets within compressed packets is not checked;
+		// compIO tracks the sequence of the compressed packets instead.
+		if !mc.compress {
+			if data[3] != mc.sequence {
+				mc.close()
+				if data[3] > mc.sequence {
+					return nil, ErrPktSyncMul
+				}
+				return nil, ErrPktSync
 			}
-			return nil, ErrPktSync
+			mc.sequence++
+		}
+
+		// compIO reports the compressed packets instead
+		if m := mc.metrics; m != nil && !mc.compress {
+			m.PacketRead(4 + pktLen)
 		}
-		mc.sequence++
 
 		// packets with length 0 terminate a previous packet which is a
 		// multiple of (2^24)-1 bytes long
 		if pktLen == 0 {
 			// there was no previous packet
 			if prevData == nil {
-				mc.log(ErrMalformPkt)
+				mc.logEvent(slog.LevelError, "reading packet failed", ErrMalformPkt)
 				mc.close()
 				return nil, ErrInvalidConn
 			}
@@ -73,7 +83,7 @@ func (mc *mysqlConn) readPacket() ([]byte, error) {
 			if cerr := mc.canceled.Value(); cerr != nil {
 				return nil, cerr
 			}
-			mc.log(err)
+			mc.logEvent(slog.LevelError, "reading packet body failed", err)
 			return nil, ErrInvalidConn
 		}
 
@@ -118,7 +128,7 @@ func (mc *mysqlConn) writePacket(data []byte) error {
 		if mc.writeTimeout > 0 {
 			if err := mc.netConn.SetWriteDeadline(time.Now().Add(mc.writeTimeout)); err != nil {
 				mc.cleanup()
-				mc.log(err)
+				mc.logEvent(slog.LevelError, "setting write deadline failed", err)
 				return err
 			}
 		}
@@ -131,7 +141,7 @@ func (mc *mysqlConn) writePacket(data []byte) error {
 			}
 			if n == 0 && pktLen == len(data)-4 {
 				// only for the first loop iteration when nothing was written yet
-				mc.log(err)
+				mc.logEvent(slog.LevelError, "writing packet failed", err)
 				return errBadConnNoWrite
 			} else {
 				return err
@@ -144,6 +154,18 @@ func (mc *mysqlConn) writePacket(data []byte) error {
 			return io.ErrShortWrite
 		}
 
+		mc.bytesSent += 4 + size
+		if m := mc.metrics; m != nil {
+			// commands are the only packets sent with sequence 0
+			if mc.sequence == 0 {
+				m.CommandSent(commandName(data[4]))
+			}
+			// compIO reports the compressed packets instead
+			if !mc.compress {
+				m.PacketWritten(4 + size)
+			}
+		}
+
 		mc.sequence++
 		if size != maxPacketSize {
 			return nil
@@ -153,6 +175,13 @@ func (mc *mysqlConn) writePacket(data []byte) error {
 	}
 }
 
+// resetSequence resets the packet sequence before a new command is sent.
+func (mc *mysqlConn) resetSequence() {
+	mc.sequence = 0
+	mc.compressSequence = 0
+	mc.sqlText = "" // set again by commands which send a statement
+}
+
 /******************************************************************************
 *                           Initialization Process                            *
 ******************************************************************************/
@@ -181,6 +210,7 @@ func (mc *mysqlConn) readHandshakePacket() (data []byte, plugin string, err erro
 	// server version [null terminated string]
 	// connection id [4 bytes]
 	pos := 1 + bytes.IndexByte(data[1:], 0x00) + 1 + 4
+	mc.connectionID = binary.LittleEndian.Uint32(data[pos-4 : pos])
 
 	// first part of the password cipher [8 bytes]
 	authData := data[pos : pos+8]
@@ -260,7 +290,10 @@ func (mc *mysqlConn) writeHandshakeResponsePacket(authResp []byte, plugin string
 		clientPluginAuth |
 		clientMultiResults |
 		mc.flags&clientConnectAttrs |
-		mc.flags&clientLongFlag
+		mc.flags&clientLongFlag |
+		mc.flags&clientSessionTrack |
+		mc.flags&clientDeprecateEOF |
+		mc.flags&clientQueryAttributes
 
 	sendConnectAttrs := mc.flags&clientConnectAttrs != 0
 
@@ -277,6 +310,32 @@ func (mc *mysqlConn) writeHandshakeResponsePacket(authResp []byte, plugin string
 		clientFlags |= clientMultiStatements
 	}
 
+	// Negotiate the compressed protocol. If the server does not support the
+	// requested algorithm, the connection stays uncompressed.
+	var zstdLevel int
+	if mc.cfg.Compress {
+		algorithm := mc.cfg.compressionAlgorithm
+		if algorithm == "" {
+			algorithm = compressionZlib
+		}
+		compressor := getCompressor(algorithm)
+		if compressor == nil {
+			return fmt.Errorf("compression algorithm '%s' is not registered", algorithm)
+		}
+		switch {
+		case algorithm == compressionZstd && mc.flags&clientZstdCompressionAlgorithm != 0:
+			clientFlags |= clientZstdCompressionAlgorithm
+			zstdLevel = mc.cfg.compressionLevel
+			if zstdLevel == 0 {
+				zstdLevel = defaultZstdCompressionLevel
+			}
+			mc.compressor = compressor
+		case algorithm == compressionZlib && mc.flags&clientCompress != 0:
+			clientFlags |= clientCompress
+			mc.compressor = compressor
+		}
+	}
+
 	// encode length of the auth plugin data
 	var authRespLEIBuf [9]byte
 	authRespLen := len(authResp)
@@ -304,6 +363,11 @@ func (mc *mysqlConn) writeHandshakeResponsePacket(authResp []byte, plugin string
 		pktLen += len(connAttrsLEI) + len(mc.connector.encodedAttributes)
 	}
 
+	// zstd compression level [1 byte]
+	if clientFlags&clientZstdCompressionAlgorithm != 0 {
+		pktLen++
+	}
+
 	// Calculate packet length and get buffer with that size
 	data, err := mc.buf.takeBuffer(pktLen + 4)
 	if err != nil {
@@ -350,8 +414,11 @@ func (mc *mysqlConn) writeHandshakeResponsePacket(authResp []byte, plugin string
 		}
 
 		// Switch to TLS
+		tlsStart := time.Now()
 		tlsConn := tls.Client(mc.netConn, mc.cfg.TLS)
-		if err := tlsConn.Handshake(); err != nil {
+		err := tlsConn.Handshake()
+		mc.traceConnectPhase(ConnectPhaseTLS, tlsStart, err)
+		if err != nil {
 			if cerr := mc.canceled.Value(); cerr != nil {
 				return cerr
 			}
@@ -389,6 +456,12 @@ func (mc *mysqlConn) writeHandshakeResponsePacket(authResp []byte, plugin string
 		pos += copy(data[pos:], []byte(mc.connector.encodedAttributes))
 	}
 
+	// zstd compression level [1 byte]
+	if clientFlags&clientZstdCompressionAlgorithm != 0 {
+		data[pos] = byte(zstdLevel)
+		pos++
+	}
+
 	// Send Auth packet
 	return mc.writePacket(data[:pos])
 }
@@ -407,13 +480,76 @@ func (mc *mysqlConn) writeAuthSwitchPacket(authData []byte) error {
 	return mc.writePacket(data)
 }
 
+// https://dev.mysql.com/doc/dev/mysql-server/latest/page_protocol_com_change_user.html
+func (mc *mysqlConn) writeChangeUserPacket(authResp []byte, plugin string) error {
+	if len(authResp) > 255 {
+		return ErrMalformPkt
+	}
+
+	// Reset Packet Sequence
+	mc.resetSequence()
+
+	pktLen := 1 + len(mc.cfg.User) + 1 + 1 + len(authResp) + len(mc.cfg.DBName) + 1 + 2 + len(plugin) + 1
+	var connAttrsLEI []byte
+	if mc.flags&clientConnectAttrs != 0 {
+		var connAttrsLEIBuf [9]byte
+		connAttrsLEI = appendLengthEncodedInteger(connAttrsLEIBuf[:0], uint64(len(mc.connector.encodedAttributes)))
+		pktLen += len(connAttrsLEI) + len(mc.connector.encodedAttributes)
+	}
+
+	data, err := mc.buf.takeBuffer(pktLen + 4)
+	if err != nil {
+		return err
+	}
+
+	// command [1 byte]
+	data[4] = comChangeUser
+	pos := 5
+
+	// user [null terminated string]
+	pos += copy(data[pos:], mc.cfg.User)
+	data[pos] = 0x00
+	pos++
+
+	// auth response [length + string]
+	data[pos] = byte(len(authResp))
+	pos++
+	pos += copy(data[pos:], authResp)
+
+	// database [null terminated string]
+	pos += copy(data[pos:], mc.cfg.DBName)
+	data[pos] = 0x00
+	pos++
+
+	// character set [2 bytes]
+	data[pos] = defaultCollationID
+	if colID, ok := collations[mc.cfg.Collation]; ok {
+		data[pos] = colID
+	}
+	data[pos+1] = 0x00
+	pos += 2
+
+	// auth plugin name [null terminated string]
+	pos += copy(data[pos:], plugin)
+	data[pos] = 0x00
+	pos++
+
+	// connection attributes
+	if len(connAttrsLEI) > 0 {
+		pos += copy(data[pos:], connAttrsLEI)
+		pos += copy(data[pos:], mc.connector.encodedAttributes)
+	}
+
+	return mc.writePacket(data[:pos])
+}
+
 /******************************************************************************
 *                             Command Packets                                 *
 ******************************************************************************/
 
 func (mc *mysqlConn) writeCommandPacket(command byte) error {
 	// Reset Packet Sequence
-	mc.sequence = 0
+	mc.resetSequence()
 
 	data, err := mc.buf.takeSmallBuffer(4 + 1)
 	if err != nil {
@@ -429,7 +565,10 @@ func (mc *mysqlConn) writeCommandPacket(command byte) error {
 
 func (mc *mysqlConn) writeCommandPacketStr(command byte, arg string) error {
 	// Reset Packet Sequence
-	mc.sequence = 0
+	mc.resetSequence()
+	if command == comQuery || command == comStmtPrepare {
+		mc.sqlText = arg
+	}
 
 	pktLen := 1 + len(arg)
 	data, err := mc.buf.takeBuffer(pktLen + 4)
@@ -447,9 +586,71 @@ func (mc *mysqlConn) writeCommandPacketStr(command byte, arg string) error {
 	return mc.writePacket(data)
 }
 
+// writeQueryPacket writes a COM_QUERY packet. If CLIENT_QUERY_ATTRIBUTES is
+// in use, the query attributes of the current statement precede the query.
+// https://dev.mysql.com/doc/dev/mysql-server/latest/page_protocol_com_query.html
+func (mc *mysqlConn) writeQueryPacket(query string) error {
+	if mc.flags&clientQueryAttributes == 0 {
+		return mc.writeCommandPacketStr(comQuery, query)
+	}
+
+	// Reset Packet Sequence
+	mc.resetSequence()
+	mc.sqlText = query
+
+	params := mc.queryAttrParams()
+	data, err := mc.buf.takeBuffer(4 + 1 + len(params) + len(query))
+	if err != nil {
+		return err
+	}
+
+	// Add command byte
+	data[4] = comQuery
+
+	// Add attributes and query
+	pos := 5 + copy(data[5:], params)
+	copy(data[pos:], query)
+
+	// Send CMD packet
+	return mc.writePacket(data)
+}
+
+// queryAttrParams returns the attributes of COM_QUERY which precede the
+// query, or nil if CLIENT_QUERY_ATTRIBUTES is not used.
+func (mc *mysqlConn) queryAttrParams() []byte {
+	if mc.flags&clientQueryAttributes == 0 {
+		return nil
+	}
+	attrs := mc.queryAttrs
+
+	// parameter_count [length encoded integer]
+	// parameter_set_count [length encoded integer], always 1
+	params := appendLengthEncodedInteger(nil, uint64(len(attrs)))
+	params = append(params, 0x01)
+	if len(attrs) > 0 {
+		// NULL-bitmap [(parameter_count + 7) / 8 bytes], attributes are never NULL
+		params = append(params, make([]byte, (len(attrs)+7)/8)...)
+
+		// new_params_bind_flag [1 byte]
+		params = append(params, 0x01)
+
+		// type [2 bytes] and name [length encoded string] of each attribute
+		for _, attr := range attrs {
+			params = append(params, byte(fieldTypeString), 0x00)
+			params = appendLengthEncodedString(params, attr.Name)
+		}
+
+		// value of each attribute [length encoded string]
+		for _, attr := range attrs {
+			params = appendLengthEncodedString(params, attr.Value)
+		}
+	}
+	return params
+}
+
 func (mc *mysqlConn) writeCommandPacketUint32(command byte, arg uint32) error {
 	// Reset Packet Sequence
-	mc.sequence = 0
+	mc.resetSequence()
 
 	data, err := mc.buf.takeSmallBuffer(4 + 1 + 4)
 	if err != nil {
@@ -562,6 +763,9 @@ func (mc *mysqlConn) handleErrorPacket(data []byte) error {
 
 	// Error Number [16 bit uint]
 	errno := binary.LittleEndian.Uint16(data[1:3])
+	if m := mc.metrics; m != nil {
+		m.ServerError(errno)
+	}
 
 	// 1792: ER_CANT_EXECUTE_IN_READ_ONLY_TRANSACTION
 	// 1290: ER_OPTION_PREVENTS_STATEMENT (returned by Aurora during failover)
@@ -575,11 +779,15 @@ func (mc *mysqlConn) handleErrorPacket(data []byte) error {
 		// We explicitly close the connection before returning
 		// driver.ErrBadConn to ensure that `database/sql` purges this
 		// connection and initiates a new one for next statement next time.
+		mc.logEvent(slog.LevelWarn, "closing connection to read-only server", nil, slog.Int("error_number", int(errno)))
 		mc.Close()
 		return driver.ErrBadConn
 	}
 
 	me := &MySQLError{Number: errno}
+	if mc.cfg.attachQueryToErrors {
+		me.Query = mc.sqlText
+	}
 
 	pos := 3
 
@@ -592,6 +800,7 @@ func (mc *mysqlConn) handleErrorPacket(data []byte) error {
 	// Error Message [string]
 	me.Message = string(data[pos:])
 
+	mc.logEvent(slog.LevelDebug, "server returned an error", me)
 	return me
 }
 
@@ -629,6 +838,7 @@ func (mc *okHandler) conn() *mysqlConn {
 // It returns a handler that can process OK responses.
 func (mc *mysqlConn) clearResult() *okHandler {
 	mc.result = mysqlResult{}
+	mc.warningCount = 0
 	return (*okHandler)(mc)
 }
 
@@ -655,14 +865,83 @@ func (mc *okHandler) handleOkPacket(data []byte) error {
 		mc.result.insertIds[len(mc.result.insertIds)-1] = int64(insertId)
 	}
 
+	return mc.conn().handleOkStatus(data[1+n+m:])
+}
+
+// handleOkStatus processes the part of an OK packet following the insert id.
+func (mc *mysqlConn) handleOkStatus(data []byte) error {
+	if len(data) < 4 {
+		return ErrMalformPkt
+	}
+
 	// server_status [2 bytes]
-	mc.status = readStatus(data[1+n+m : 1+n+m+2])
-	if mc.status&statusMoreResultsExists != 0 {
+	mc.status = readStatus(data[0:2])
+	pos := 2
+
+	// warning count [2 bytes]
+	mc.warningCount = binary.LittleEndian.Uint16(data[pos : pos+2])
+	pos += 2
+
+	if mc.flags&clientSessionTrack == 0 || pos >= len(data) {
+		// info [string<EOF>]
 		return nil
 	}
 
-	// warning count [2 bytes]
+	// info [length encoded string]
+	n, err := skipLengthEncodedString(data[pos:])
+	if err != nil {
+		return err
+	}
+	pos += n
+
+	// session state info [length encoded string]
+	if mc.status&statusSessionStateChanged != 0 {
+		changes, _, _, err := readLengthEncodedString(data[pos:])
+		if err != nil {
+			return err
+		}
+		return mc.handleSessionStateChanges(changes)
+	}
+	return nil
+}
+
+// isEOFPacket returns true if data terminates a list of column definitions or
+// rows. This is either an EOF packet or, if CLIENT_DEPRECATE_EOF is in use, an
+// OK packet with the EOF header.
+// Row packets may start with 0xfe, too, but then they are at least
+// 0xffffff bytes long.
+func (mc *mysqlConn) isEOFPacket(data []byte) bool {
+	if data[0] != iEOF {
+		return false
+	}
+	if mc.flags&clientDeprecateEOF != 0 {
+		return len(data) < maxPacketSize
+	}
+	return len(data) < 9
+}
+
+// handleEOFPacket processes a packet for which isEOFPacket returned true.
+// https://dev.mysql.com/doc/dev/mysql-server/latest/page_protocol_basic_eof_packet.html
+func (mc *mysqlConn) handleEOFPacket(data []byte) error {
+	if mc.flags&clientDeprecateEOF != 0 {
+		// The OK packet of a result set does not affect the result.
+		// affected rows and last insert id [length encoded integers]
+		pos := 1
+		for i := 0; i < 2; i++ {
+			n, err := skipLengthEncodedInteger(data[pos:])
+			if err != nil {
+				return err
+			}
+			pos += n
+		}
+		return mc.handleOkStatus(data[pos:])
+	}
 
+	if len(data) == 5 {
+		// warning count [2 bytes], server_status [2 bytes]
+		mc.warningCount = binary.LittleEndian.Uint16(data[1:3])
+		mc.status = readStatus(data[3:])
+	}
 	return nil
 }
 
@@ -672,13 +951,22 @@ func (mc *mysqlConn) readColumns(count int) ([]mysqlField, error) {
 	columns := make([]mysqlField, count)
 
 	for i := 0; ; i++ {
+		if i == count && mc.flags&clientDeprecateEOF != 0 {
+			// no EOF packet follows the column definitions
+			return columns, nil
+		}
+
 		data, err := mc.readPacket()
 		if err != nil {
 			return nil, err
 		}
 
 		// EOF Packet
-		if data[0] == iEOF && (len(data) == 5 || len(data) == 1) {
+		if mc.isEOFPacket(data) {
+			// server_status, e.g. whether a cursor was opened
+			if err := mc.handleEOFPacket(data); err != nil {
+				return nil, err
+			}
 			if i == count {
 				return columns, nil
 			}
@@ -781,9 +1069,15 @@ func (rows *textRows) readRow(dest []driver.Value) error {
 	}
 
 	// EOF Packet
-	if data[0] == iEOF && len(data) == 5 {
-		// server_status [2 bytes]
-		rows.mc.status = readStatus(data[3:])
+	if mc.isEOFPacket(data) {
+		if err := mc.handleEOFPacket(data); err != nil {
+			rows.mc = nil
+			return err
+		}
+		if err := rows.readWarnings(mc); err != nil {
+			rows.mc = nil
+			return err
+		}
 		rows.rs.done = true
 		if !rows.HasNextResultSet() {
 			rows.mc = nil
@@ -865,16 +1159,32 @@ func (mc *mysqlConn) readUntilEOF() error {
 			return err
 		}
 
-		switch data[0] {
-		case iERR:
+		switch {
+		case data[0] == iERR:
+			return mc.handleErrorPacket(data)
+		case mc.isEOFPacket(data):
+			return mc.handleEOFPacket(data)
+		}
+	}
+}
+
+// skipColumns discards count column definitions, including the EOF packet
+// which follows them unless CLIENT_DEPRECATE_EOF is in use.
+func (mc *mysqlConn) skipColumns(count int) error {
+	if mc.flags&clientDeprecateEOF == 0 {
+		return mc.readUntilEOF()
+	}
+
+	for i := 0; i < count; i++ {
+		data, err := mc.readPacket()
+		if err != nil {
+			return err
+		}
+		if data[0] == iERR {
 			return mc.handleErrorPacket(data)
-		case iEOF:
-			if len(data) == 5 {
-				mc.status = readStatus(data[3:])
-			}
-			return nil
 		}
 	}
+	return nil
 }
 
 /******************************************************************************
@@ -896,6 +1206,7 @@ func (stmt *mysqlStmt) readPrepareResultPacket() (uint16, error) {
 
 		// Column count [16 bit uint]
 		columnCount := binary.LittleEndian.Uint16(data[5:7])
+		stmt.columnCount = int(columnCount)
 
 		// Param count [16 bit uint]
 		stmt.paramCount = int(binary.LittleEndian.Uint16(data[7:9]))
@@ -932,7 +1243,7 @@ func (stmt *mysqlStmt) writeCommandLongData(paramID int, arg []byte) error {
 			pktLen = dataOffset + argLen
 		}
 
-		stmt.mc.sequence = 0
+		stmt.mc.resetSequence()
 		// Add command byte [1 byte]
 		data[4] = comStmtSendLongData
 
@@ -957,13 +1268,13 @@ func (stmt *mysqlStmt) writeCommandLongData(paramID int, arg []byte) error {
 	}
 
 	// Reset Packet Sequence
-	stmt.mc.sequence = 0
+	stmt.mc.resetSequence()
 	return nil
 }
 
 // Execute Prepared Statement
 // http://dev.mysql.com/doc/internals/en/com-stmt-execute.html
-func (stmt *mysqlStmt) writeExecutePacket(args []driver.Value) error {
+func (stmt *mysqlStmt) writeExecutePacket(args []driver.Value, cursorType byte) error {
 	if len(args) != stmt.paramCount {
 		return fmt.Errorf(
 			"argument count mismatch (got: %d; has: %d)",
@@ -975,6 +1286,15 @@ func (stmt *mysqlStmt) writeExecutePacket(args []driver.Value) error {
 	const minPktLen = 4 + 1 + 4 + 1 + 4
 	mc := stmt.mc
 
+	// Query attributes are sent as named parameters after the arguments.
+	// The arguments get an empty name then.
+	var attrs []QueryAttribute
+	withAttrs := mc.flags&clientQueryAttributes != 0
+	if withAttrs {
+		attrs = mc.queryAttrs
+	}
+	numParams := len(args) + len(attrs)
+
 	// Determine threshold dynamically to avoid packet size shortage.
 	longDataSize := mc.maxAllowedPacket / (stmt.paramCount + 1)
 	if longDataSize < 64 {
@@ -982,12 +1302,13 @@ func (stmt *mysqlStmt) writeExecutePacket(args []driver.Value) error {
 	}
 
 	// Reset packet-sequence
-	mc.sequence = 0
+	mc.resetSequence()
+	mc.sqlText = stmt.sql
 
 	var data []byte
 	var err error
 
-	if len(args) == 0 {
+	if numParams == 0 {
 		data, err = mc.buf.takeBuffer(minPktLen)
 	} else {
 		data, err = mc.buf.takeCompleteBuffer()
@@ -1006,8 +1327,11 @@ func (stmt *mysqlStmt) writeExecutePacket(args []driver.Value) error {
 	data[7] = byte(stmt.id >> 16)
 	data[8] = byte(stmt.id >> 24)
 
-	// flags (0: CURSOR_TYPE_NO_CURSOR) [1 byte]
-	data[9] = 0x00
+	// flags (cursor type) [1 byte]
+	data[9] = cursorType
+	if len(args) == 0 && len(attrs) > 0 {
+		data[9] |= parameterCountAvailable
+	}
 
 	// iteration_count (uint32(1)) [4 bytes]
 	data[10] = 0x01
@@ -1015,16 +1339,33 @@ func (stmt *mysqlStmt) writeExecutePacket(args []driver.Value) error {
 	data[12] = 0x00
 	data[13] = 0x00
 
-	if len(args) > 0 {
+	if numParams > 0 {
 		pos := minPktLen
 
+		// type of each parameter [2 bytes], followed by its name
+		// [length encoded string] if query attributes are in use
+		stride := 2
+		typesLen := 2 * len(args)
+		var attrTypes []byte
+		if withAttrs {
+			// parameter_count [length encoded integer]
+			pos += len(appendLengthEncodedInteger(data[pos:pos], uint64(numParams)))
+
+			for _, attr := range attrs {
+				attrTypes = append(attrTypes, byte(fieldTypeString), 0x00)
+				attrTypes = appendLengthEncodedString(attrTypes, attr.Name)
+			}
+			stride = 3
+			typesLen = 3*len(args) + len(attrTypes)
+		}
+
 		var nullMask []byte
-		if maskLen, typesLen := (len(args)+7)/8, 1+2*len(args); pos+maskLen+typesLen >= cap(data) {
+		if maskLen := (numParams + 7) / 8; pos+maskLen+1+typesLen >= cap(data) {
 			// buffer has to be extended but we don't know by how much so
 			// we depend on append after all data with known sizes fit.
 			// We stop at that because we deal with a lot of columns here
 			// which makes the required allocation size hard to guess.
-			tmp := make([]byte, pos+maskLen+typesLen)
+			tmp := make([]byte, pos+maskLen+1+typesLen)
 			copy(tmp[:pos], data[:pos])
 			data = tmp
 			nullMask = data[pos : pos+maskLen]
@@ -1042,9 +1383,16 @@ func (stmt *mysqlStmt) writeExecutePacket(args []driver.Value) error {
 		data[pos] = 0x01
 		pos++
 
-		// type of each parameter [len(args)*2 bytes]
 		paramTypes := data[pos:]
-		pos += len(args) * 2
+		pos += typesLen
+
+		if withAttrs {
+			// arguments have an empty name
+			for i := range args {
+				paramTypes[i*stride+2] = 0x00
+			}
+			copy(paramTypes[len(args)*stride:], attrTypes)
+		}
 
 		// value of each parameter [n bytes]
 		paramValues := data[pos:pos]
@@ -1054,8 +1402,8 @@ func (stmt *mysqlStmt) writeExecutePacket(args []driver.Value) error {
 			// build NULL-bitmap
 			if arg == nil {
 				nullMask[i/8] |= 1 << (uint(i) & 7)
-				paramTypes[i+i] = byte(fieldTypeNULL)
-				paramTypes[i+i+1] = 0x00
+				paramTypes[i*stride] = byte(fieldTypeNULL)
+				paramTypes[i*stride+1] = 0x00
 				continue
 			}
 
@@ -1065,8 +1413,8 @@ func (stmt *mysqlStmt) writeExecutePacket(args []driver.Value) error {
 			// cache types and values
 			switch v := arg.(type) {
 			case int64:
-				paramTypes[i+i] = byte(fieldTypeLongLong)
-				paramTypes[i+i+1] = 0x00
+				paramTypes[i*stride] = byte(fieldTypeLongLong)
+				paramTypes[i*stride+1] = 0x00
 
 				if cap(paramValues)-len(paramValues)-8 >= 0 {
 					paramValues = paramValues[:len(paramValues)+8]
@@ -1081,8 +1429,8 @@ func (stmt *mysqlStmt) writeExecutePacket(args []driver.Value) error {
 				}
 
 			case uint64:
-				paramTypes[i+i] = byte(fieldTypeLongLong)
-				paramTypes[i+i+1] = 0x80 // type is unsigned
+				paramTypes[i*stride] = byte(fieldTypeLongLong)
+				paramTypes[i*stride+1] = 0x80 // type is unsigned
 
 				if cap(paramValues)-len(paramValues)-8 >= 0 {
 					paramValues = paramValues[:len(paramValues)+8]
@@ -1097,8 +1445,8 @@ func (stmt *mysqlStmt) writeExecutePacket(args []driver.Value) error {
 				}
 
 			case float64:
-				paramTypes[i+i] = byte(fieldTypeDouble)
-				paramTypes[i+i+1] = 0x00
+				paramTypes[i*stride] = byte(fieldTypeDouble)
+				paramTypes[i*stride+1] = 0x00
 
 				if cap(paramValues)-len(paramValues)-8 >= 0 {
 					paramValues = paramValues[:len(paramValues)+8]
@@ -1113,8 +1461,8 @@ func (stmt *mysqlStmt) writeExecutePacket(args []driver.Value) error {
 				}
 
 			case bool:
-				paramTypes[i+i] = byte(fieldTypeTiny)
-				paramTypes[i+i+1] = 0x00
+				paramTypes[i*stride] = byte(fieldTypeTiny)
+				paramTypes[i*stride+1] = 0x00
 
 				if v {
 					paramValues = append(paramValues, 0x01)
@@ -1125,8 +1473,8 @@ func (stmt *mysqlStmt) writeExecutePacket(args []driver.Value) error {
 			case []byte:
 				// Common case (non-nil value) first
 				if v != nil {
-					paramTypes[i+i] = byte(fieldTypeString)
-					paramTypes[i+i+1] = 0x00
+					paramTypes[i*stride] = byte(fieldTypeString)
+					paramTypes[i*stride+1] = 0x00
 
 					if len(v) < longDataSize {
 						paramValues = appendLengthEncodedInteger(paramValues,
@@ -1143,12 +1491,12 @@ func (stmt *mysqlStmt) writeExecutePacket(args []driver.Value) error {
 
 				// Handle []byte(nil) as a NULL value
 				nullMask[i/8] |= 1 << (uint(i) & 7)
-				paramTypes[i+i] = byte(fieldTypeNULL)
-				paramTypes[i+i+1] = 0x00
+				paramTypes[i*stride] = byte(fieldTypeNULL)
+				paramTypes[i*stride+1] = 0x00
 
 			case string:
-				paramTypes[i+i] = byte(fieldTypeString)
-				paramTypes[i+i+1] = 0x00
+				paramTypes[i*stride] = byte(fieldTypeString)
+				paramTypes[i*stride+1] = 0x00
 
 				if len(v) < longDataSize {
 					paramValues = appendLengthEncodedInteger(paramValues,
@@ -1162,8 +1510,8 @@ func (stmt *mysqlStmt) writeExecutePacket(args []driver.Value) error {
 				}
 
 			case time.Time:
-				paramTypes[i+i] = byte(fieldTypeString)
-				paramTypes[i+i+1] = 0x00
+				paramTypes[i*stride] = byte(fieldTypeString)
+				paramTypes[i*stride+1] = 0x00
 
 				var a [64]byte
 				var b = a[:0]
@@ -1187,6 +1535,10 @@ func (stmt *mysqlStmt) writeExecutePacket(args []driver.Value) error {
 			}
 		}
 
+		for _, attr := range attrs {
+			paramValues = appendLengthEncodedString(paramValues, attr.Value)
+		}
+
 		// Check if param values exceeded the available buffer
 		// In that case we must build the data packet with the new values buffer
 		if valuesCap != cap(paramValues) {
@@ -1211,7 +1563,7 @@ func (mc *okHandler) discardResults() error {
 		}
 		if resLen > 0 {
 			// columns
-			if err := mc.conn().readUntilEOF(); err != nil {
+			if err := mc.conn().skipColumns(resLen); err != nil {
 				return err
 			}
 			// rows
@@ -1225,16 +1577,27 @@ func (mc *okHandler) discardResults() error {
 
 // http://dev.mysql.com/doc/internals/en/binary-protocol-resultset-row.html
 func (rows *binaryRows) readRow(dest []driver.Value) error {
-	data, err := rows.mc.readPacket()
-	if err != nil {
-		return err
+	data := rows.pending
+	rows.pending = nil
+	if data == nil {
+		var err error
+		if data, err = rows.mc.readPacket(); err != nil {
+			return err
+		}
 	}
 
 	// packet indicator [1 byte]
 	if data[0] != iOK {
 		// EOF Packet
-		if data[0] == iEOF && len(data) == 5 {
-			rows.mc.status = readStatus(data[3:])
+		if rows.mc.isEOFPacket(data) {
+			if err := rows.mc.handleEOFPacket(data); err != nil {
+				rows.mc = nil
+				return err
+			}
+			if err := rows.readWarnings(rows.mc); err != nil {
+				rows.mc = nil
+				return err
+			}
 			rows.rs.done = true
 			if !rows.HasNextResultSet() {
 				rows.mc = nil
@@ -1248,6 +1611,11 @@ func (rows *binaryRows) readRow(dest []driver.Value) error {
 		return mc.handleErrorPacket(data)
 	}
 
+	return rows.parseRow(data, dest)
+}
+
+// parseRow decodes the binary row packet data into dest.
+func (rows *binaryRows) parseRow(data []byte, dest []driver.Value) (err error) {
 	// NULL-bitmap,  [(column-count + 7 + 2) / 8 bytes]
 	pos := 1 + (len(dest)+7+2)>>3
 	nullMask := data[1:pos]
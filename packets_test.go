@@ -10,7 +10,9 @@ package mysql
 
 import (
 	"bytes"
+	"database/sql/driver"
 	"errors"
+	"io"
 	"net"
 	"testing"
 	"time"
@@ -109,6 +111,18 @@ func newRWMockConn(sequence uint8) (*mockConn, *mysqlConn) {
 	return conn, mc
 }
 
+// makePackets frames the payloads as consecutive packets, starting with the
+// given sequence id.
+func makePackets(sequence uint8, payloads ...[]byte) []byte {
+	var data []byte
+	for _, p := range payloads {
+		data = append(data, byte(len(p)), byte(len(p)>>8), byte(len(p)>>16), sequence)
+		data = append(data, p...)
+		sequence++
+	}
+	return data
+}
+
 func TestReadPacketSingleByte(t *testing.T) {
 	conn := new(mockConn)
 	mc := &mysqlConn{
@@ -341,3 +355,156 @@ func TestRegression801(t *testing.T) {
 		t.Errorf("expected authData '%v', got '%v'", expectedAuthData, authData)
 	}
 }
+
+// makeColumnDefinition returns a column definition packet payload.
+func makeColumnDefinition(name string, typ fieldType) []byte {
+	data := []byte{3, 'd', 'e', 'f', 0, 0, 0, byte(len(name))}
+	data = append(data, name...)
+	return append(data, 0, 0x0c, 0x3f, 0x00, 0x14, 0x00, 0x00, 0x00,
+		byte(typ), 0x00, 0x00, 0x00, 0x00, 0x00)
+}
+
+// makeEOFOK returns the OK packet which terminates a result set if
+// CLIENT_DEPRECATE_EOF is in use.
+func makeEOFOK(status statusFlag, stateInfo []byte) []byte {
+	data := []byte{iEOF, 0x00, 0x00, byte(status), byte(status >> 8), 0x00, 0x00}
+	if stateInfo == nil {
+		return data
+	}
+	data = append(data, 0x00, byte(len(stateInfo)))
+	return append(data, stateInfo...)
+}
+
+func TestDeprecateEOFTextResultSet(t *testing.T) {
+	conn, mc := newRWMockConn(0)
+	mc.flags = clientDeprecateEOF | clientSessionTrack
+
+	status := statusSessionStateChanged | statusInAutocommit
+	conn.queuedReplies = [][]byte{makePackets(1,
+		[]byte{0x01},
+		makeColumnDefinition("id", fieldTypeLongLong),
+		[]byte{0x01, '1'},
+		[]byte{0x01, '2'},
+		makeEOFOK(status, []byte{byte(sessionTrackSchema), 0x04, 0x03, 'd', 'b', '2'}),
+	)}
+
+	rows, err := mc.query("SELECT id FROM t", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows.rs.columns) != 1 || rows.rs.columns[0].name != "id" {
+		t.Fatalf("unexpected columns: %+v", rows.rs.columns)
+	}
+
+	dest := make([]driver.Value, 1)
+	for want := int64(1); want <= 2; want++ {
+		if err := rows.Next(dest); err != nil {
+			t.Fatalf("row %d: %v", want, err)
+		}
+		if dest[0] != want {
+			t.Errorf("expected %d, got %v", want, dest[0])
+		}
+	}
+	if err := rows.Next(dest); err != io.EOF {
+		t.Fatalf("expected io.EOF, got %v", err)
+	}
+
+	if mc.status != status {
+		t.Errorf("expected status %v, got %v", status, mc.status)
+	}
+	if schema := mc.SessionState().Schema; schema != "db2" {
+		t.Errorf("expected schema db2, got %q", schema)
+	}
+	if len(conn.data) != 0 {
+		t.Errorf("%d bytes left unread", len(conn.data))
+	}
+}
+
+func TestDeprecateEOFExecDiscardsRows(t *testing.T) {
+	conn, mc := newRWMockConn(0)
+	mc.flags = clientDeprecateEOF
+
+	conn.queuedReplies = [][]byte{makePackets(1,
+		[]byte{0x01},
+		makeColumnDefinition("id", fieldTypeLongLong),
+		[]byte{0x01, '1'},
+		makeEOFOK(statusMoreResultsExists, nil),
+		[]byte{iOK, 0x03, 0x00, 0x02, 0x00, 0x00, 0x00},
+	)}
+
+	if err := mc.exec("CALL p()"); err != nil {
+		t.Fatal(err)
+	}
+	if mc.result.affectedRows[len(mc.result.affectedRows)-1] != 3 {
+		t.Errorf("unexpected affected rows: %v", mc.result.affectedRows)
+	}
+	if len(conn.data) != 0 {
+		t.Errorf("%d bytes left unread", len(conn.data))
+	}
+}
+
+func TestDeprecateEOFPrepare(t *testing.T) {
+	conn, mc := newRWMockConn(0)
+	mc.flags = clientDeprecateEOF
+
+	conn.queuedReplies = [][]byte{makePackets(1,
+		// statement id 1, 1 column, 1 parameter
+		[]byte{iOK, 0x01, 0x00, 0x00, 0x00, 0x01, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00},
+		makeColumnDefinition("?", fieldTypeNULL),
+		makeColumnDefinition("id", fieldTypeLongLong),
+	)}
+
+	stmt, err := mc.Prepare("SELECT id FROM t WHERE id = ?")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := stmt.NumInput(); n != 1 {
+		t.Errorf("expected 1 parameter, got %d", n)
+	}
+	if len(conn.data) != 0 {
+		t.Errorf("%d bytes left unread", len(conn.data))
+	}
+}
+
+func TestIsEOFPacket(t *testing.T) {
+	_, mc := newRWMockConn(0)
+
+	// a row starting with a length encoded string of 2^24 bytes or more
+	row := make([]byte, maxPacketSize)
+	row[0] = iEOF
+
+	if !mc.isEOFPacket(makeEOF(0)) {
+		t.Error("EOF packet not detected")
+	}
+	if mc.isEOFPacket(row) {
+		t.Error("row detected as EOF packet")
+	}
+
+	mc.flags = clientDeprecateEOF
+	if !mc.isEOFPacket(makeEOFOK(0, nil)) {
+		t.Error("OK packet with EOF header not detected")
+	}
+	if mc.isEOFPacket(row) {
+		t.Error("row detected as EOF packet")
+	}
+}
+
+func TestHandleEOFPacketTruncated(t *testing.T) {
+	_, mc := newRWMockConn(0)
+	mc.flags = clientDeprecateEOF
+
+	for _, data := range [][]byte{
+		{iEOF},
+		{iEOF, 0x00},
+		{iEOF, 0xfc, 0x01},
+		{iEOF, 0x00, 0xfe, 0x01},
+		{iEOF, 0x00, 0x00, 0x02, 0x00},
+	} {
+		if !mc.isEOFPacket(data) {
+			t.Fatalf("%v not detected as EOF packet", data)
+		}
+		if err := mc.handleEOFPacket(data); err != ErrMalformPkt {
+			t.Errorf("%v: expected ErrMalformPkt, got %v", data, err)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+// Go MySQL Driver - A MySQL-Driver for Go's database/sql package
+//
+// Copyright 2024 The Go-MySQL-Driver Authors. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package mysql
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// PasswordProvider supplies the password for new connections, e.g. a
+// short-lived token of a cloud IAM service.
+type PasswordProvider interface {
+	// Password returns the password and the time it expires. The password
+	// is reused for new connections until then. A zero expiry time means
+	// the password does not expire.
+	Password(ctx context.Context) (password string, expiry time.Time, err error)
+}
+
+// PasswordProviderFunc adapts a function to the PasswordProvider interface.
+type PasswordProviderFunc func(ctx context.Context) (string, time.Time, error)
+
+// Password implements PasswordProvider.
+func (f PasswordProviderFunc) Password(ctx context.Context) (string, time.Time, error) {
+	return f(ctx)
+}
+
+// passwordCache caches the password of a PasswordProvider until it expires.
+// It is shared by all copies of a Config. Concurrent requests for a new
+// password share a single call of the provider.
+type passwordCache struct {
+	provider PasswordProvider
+
+	mu       sync.Mutex
+	password string
+	expiry   time.Time
+	valid    bool
+	fetch    *passwordFetch // call of the provider in progress, if any
+}
+
+// passwordFetch is a call of the provider of a passwordCache.
+type passwordFetch struct {
+	done     chan struct{} // closed when the call returned
+	password string
+	err      error
+}
+
+// get returns the cached password, or a new one from the provider if the
+// cached one expired. fetched reports whether the password has just been
+// returned by the provider.
+func (c *passwordCache) get(ctx context.Context) (password string, fetched bool, err error) {
+	c.mu.Lock()
+	if c.valid && (c.expiry.IsZero() || time.Now().Before(c.expiry)) {
+		password = c.password
+		c.mu.Unlock()
+		return password, false, nil
+	}
+	return c.fetchLocked(ctx)
+}
+
+// refresh returns a new password from the provider after the server
+// rejected the password rejected. If the cached password has already been
+// replaced, e.g. by a concurrent refresh, the cached one is returned.
+func (c *passwordCache) refresh(ctx context.Context, rejected string) (string, error) {
+	c.mu.Lock()
+	if c.valid && c.password != rejected && (c.expiry.IsZero() || time.Now().Before(c.expiry)) {
+		password := c.password
+		c.mu.Unlock()
+		return password, nil
+	}
+	password, _, err := c.fetchLocked(ctx)
+	return password, err
+}
+
+// fetchLocked returns a new password from the provider, joining the call in
+// progress if there is one. c.mu must be held and is released.
+func (c *passwordCache) fetchLocked(ctx context.Context) (string, bool, error) {
+	if f := c.fetch; f != nil {
+		c.mu.Unlock()
+		select {
+		case <-f.done:
+			return f.password, true, f.err
+		case <-ctx.Done():
+			return "", false, ctx.Err()
+		}
+	}
+	f := &passwordFetch{done: make(chan struct{})}
+	c.fetch = f
+	c.mu.Unlock()
+
+	password, expiry, err := c.provider.Password(ctx)
+	f.password, f.err = password, err
+
+	c.mu.Lock()
+	c.fetch = nil
+	if err != nil {
+		c.valid = false
+	} else {
+		c.password = password
+		c.expiry = expiry
+		c.valid = true
+	}
+	c.mu.Unlock()
+	close(f.done)
+	return password, true, err
+}
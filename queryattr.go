@@ -0,0 +1,42 @@
+// Go MySQL Driver - A MySQL-Driver for Go's database/sql package
+//
+// Copyright 2024 The Go-MySQL-Driver Authors. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package mysql
+
+import "context"
+
+// QueryAttribute is a named value sent along with a statement (MySQL 8.0.23+).
+// The server does not use it to execute the statement, but makes it
+// available to plugins, e.g. for audit logging, and to the SQL function
+// mysql_query_attribute_string().
+type QueryAttribute struct {
+	Name  string
+	Value string
+}
+
+type queryAttributesKey struct{}
+
+// WithQueryAttributes returns a copy of ctx which attaches attrs to every
+// statement executed with it, after the attributes already attached to ctx.
+//
+//	ctx = mysql.WithQueryAttributes(ctx, mysql.QueryAttribute{Name: "trace_id", Value: traceID})
+//	rows, err := db.QueryContext(ctx, "SELECT ...")
+//
+// Attributes are ignored by servers which do not support them.
+func WithQueryAttributes(ctx context.Context, attrs ...QueryAttribute) context.Context {
+	prev := queryAttributes(ctx)
+	merged := make([]QueryAttribute, 0, len(prev)+len(attrs))
+	merged = append(append(merged, prev...), attrs...)
+	return context.WithValue(ctx, queryAttributesKey{}, merged)
+}
+
+// queryAttributes returns the query attributes attached to ctx.
+func queryAttributes(ctx context.Context) []QueryAttribute {
+	attrs, _ := ctx.Value(queryAttributesKey{}).([]QueryAttribute)
+	return attrs
+}
@@ -0,0 +1,168 @@
+// Go MySQL Driver - A MySQL-Driver for Go's database/sql package
+//
+// Copyright 2024 The Go-MySQL-Driver Authors. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package mysql
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"reflect"
+	"testing"
+)
+
+var okPacket = []byte{iOK, 0x00, 0x00, 0x02, 0x00, 0x00, 0x00}
+
+func TestWithQueryAttributes(t *testing.T) {
+	ctx := WithQueryAttributes(context.Background(), QueryAttribute{"a", "1"})
+	ctx2 := WithQueryAttributes(ctx, QueryAttribute{"b", "2"})
+
+	if got := queryAttributes(ctx); !reflect.DeepEqual(got, []QueryAttribute{{"a", "1"}}) {
+		t.Errorf("unexpected attributes %v", got)
+	}
+	if got := queryAttributes(ctx2); !reflect.DeepEqual(got, []QueryAttribute{{"a", "1"}, {"b", "2"}}) {
+		t.Errorf("unexpected attributes %v", got)
+	}
+}
+
+func TestQueryAttributesComQuery(t *testing.T) {
+	conn, mc := newRWMockConn(0)
+	mc.flags = clientQueryAttributes
+	conn.queuedReplies = [][]byte{makePackets(1, okPacket), makePackets(1, okPacket)}
+
+	ctx := WithQueryAttributes(context.Background(), QueryAttribute{"trace", "abc"})
+	if _, err := mc.ExecContext(ctx, "DO 1", nil); err != nil {
+		t.Fatal(err)
+	}
+	expected := []byte{comQuery,
+		0x01,       // parameter_count
+		0x01,       // parameter_set_count
+		0x00,       // NULL-bitmap
+		0x01,       // new_params_bind_flag
+		0xfe, 0x00, // type
+		0x05, 't', 'r', 'a', 'c', 'e', // name
+		0x03, 'a', 'b', 'c', // value
+		'D', 'O', ' ', '1',
+	}
+	if !bytes.Equal(conn.written, makePackets(0, expected)) {
+		t.Errorf("expected %v, got %v", makePackets(0, expected), conn.written)
+	}
+
+	// statements without attributes still send the parameter counts
+	conn.written = nil
+	if _, err := mc.ExecContext(context.Background(), "DO 1", nil); err != nil {
+		t.Fatal(err)
+	}
+	expected = []byte{comQuery, 0x00, 0x01, 'D', 'O', ' ', '1'}
+	if !bytes.Equal(conn.written, makePackets(0, expected)) {
+		t.Errorf("expected %v, got %v", makePackets(0, expected), conn.written)
+	}
+	if mc.queryAttrs != nil {
+		t.Error("query attributes were not cleared")
+	}
+}
+
+func TestQueryAttributesIgnoredByOldServers(t *testing.T) {
+	conn, mc := newRWMockConn(0)
+	conn.queuedReplies = [][]byte{makePackets(1, okPacket)}
+
+	ctx := WithQueryAttributes(context.Background(), QueryAttribute{"trace", "abc"})
+	if _, err := mc.ExecContext(ctx, "DO 1", nil); err != nil {
+		t.Fatal(err)
+	}
+	if expected := makePackets(0, []byte{comQuery, 'D', 'O', ' ', '1'}); !bytes.Equal(conn.written, expected) {
+		t.Errorf("expected %v, got %v", expected, conn.written)
+	}
+}
+
+func TestQueryAttributesComStmtExecute(t *testing.T) {
+	conn, mc := newRWMockConn(0)
+	mc.flags = clientQueryAttributes
+	stmt := &mysqlStmt{mc: mc, id: 1, paramCount: 1}
+	conn.queuedReplies = [][]byte{makePackets(1, okPacket)}
+
+	ctx := WithQueryAttributes(context.Background(), QueryAttribute{"t", "x"})
+	args := []driver.NamedValue{{Ordinal: 1, Value: int64(7)}}
+	if _, err := stmt.ExecContext(ctx, args); err != nil {
+		t.Fatal(err)
+	}
+	expected := []byte{comStmtExecute,
+		0x01, 0x00, 0x00, 0x00, // statement_id
+		0x00,                   // flags
+		0x01, 0x00, 0x00, 0x00, // iteration_count
+		0x02,             // parameter_count
+		0x00,             // NULL-bitmap
+		0x01,             // new_params_bind_flag
+		0x08, 0x00, 0x00, // argument: type, empty name
+		0xfe, 0x00, 0x01, 't', // attribute: type, name
+		0x07, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // argument value
+		0x01, 'x', // attribute value
+	}
+	if !bytes.Equal(conn.written, makePackets(0, expected)) {
+		t.Errorf("expected %v, got %v", makePackets(0, expected), conn.written)
+	}
+}
+
+func TestQueryAttributesComStmtExecuteWithoutArguments(t *testing.T) {
+	conn, mc := newRWMockConn(0)
+	mc.flags = clientQueryAttributes
+	stmt := &mysqlStmt{mc: mc, id: 1}
+	conn.queuedReplies = [][]byte{makePackets(1, okPacket), makePackets(1, okPacket)}
+
+	ctx := WithQueryAttributes(context.Background(), QueryAttribute{"t", "x"})
+	if _, err := stmt.ExecContext(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	expected := []byte{comStmtExecute,
+		0x01, 0x00, 0x00, 0x00, // statement_id
+		parameterCountAvailable, // flags
+		0x01, 0x00, 0x00, 0x00,  // iteration_count
+		0x01,                  // parameter_count
+		0x00,                  // NULL-bitmap
+		0x01,                  // new_params_bind_flag
+		0xfe, 0x00, 0x01, 't', // attribute: type, name
+		0x01, 'x', // attribute value
+	}
+	if !bytes.Equal(conn.written, makePackets(0, expected)) {
+		t.Errorf("expected %v, got %v", makePackets(0, expected), conn.written)
+	}
+
+	// without attributes nothing follows the iteration count
+	conn.written = nil
+	if _, err := stmt.ExecContext(context.Background(), nil); err != nil {
+		t.Fatal(err)
+	}
+	expected = []byte{comStmtExecute, 0x01, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00}
+	if !bytes.Equal(conn.written, makePackets(0, expected)) {
+		t.Errorf("expected %v, got %v", makePackets(0, expected), conn.written)
+	}
+}
+
+func TestQueryAttributesComStmtExecuteIgnoredByOldServers(t *testing.T) {
+	conn, mc := newRWMockConn(0)
+	stmt := &mysqlStmt{mc: mc, id: 1, paramCount: 1}
+	conn.queuedReplies = [][]byte{makePackets(1, okPacket)}
+
+	ctx := WithQueryAttributes(context.Background(), QueryAttribute{"t", "x"})
+	args := []driver.NamedValue{{Ordinal: 1, Value: int64(7)}}
+	if _, err := stmt.ExecContext(ctx, args); err != nil {
+		t.Fatal(err)
+	}
+	expected := []byte{comStmtExecute,
+		0x01, 0x00, 0x00, 0x00, // statement_id
+		0x00,                   // flags
+		0x01, 0x00, 0x00, 0x00, // iteration_count
+		0x00,       // NULL-bitmap
+		0x01,       // new_params_bind_flag
+		0x08, 0x00, // argument type
+		0x07, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // argument value
+	}
+	if !bytes.Equal(conn.written, makePackets(0, expected)) {
+		t.Errorf("expected %v, got %v", makePackets(0, expected), conn.written)
+	}
+}
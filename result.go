@@ -21,12 +21,18 @@ type Result interface {
 	AllRowsAffected() []int64
 	// AllLastInsertIds, her yürütülen ifade için son eklenen kimliği içeren bir dilim döndürür.
 	AllLastInsertIds() []int64
+	// WarningCount, son yürütülen ifadenin uyarı sayısını döndürür.
+	WarningCount() uint16
+	// Warnings, fetchWarnings etkinse SHOW WARNINGS ile okunan uyarıları döndürür.
+	Warnings() []Warning
 }
 
 type mysqlResult struct {
 	// Her yürütülen ifade sonucu için her iki dilimde bir giriş oluşturulur.
 	affectedRows []int64
 	insertIds    []int64
+	warningCount uint16
+	warnings     []Warning
 }
 
 func (res *mysqlResult) LastInsertId() (int64, error) {
@@ -50,3 +56,11 @@ func (res *mysqlResult) AllLastInsertIds() []int64 {
 func (res *mysqlResult) AllRowsAffected() []int64 {
 	return append([]int64{}, res.affectedRows...)
 }
+
+func (res *mysqlResult) WarningCount() uint16 {
+	return res.warningCount
+}
+
+func (res *mysqlResult) Warnings() []Warning {
+	return append([]Warning(nil), res.warnings...)
+}
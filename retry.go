@@ -0,0 +1,94 @@
+// Go MySQL Driver - A MySQL-Driver for Go's database/sql package
+//
+// Copyright 2024 The Go-MySQL-Driver Authors. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package mysql
+
+import (
+	"context"
+	"log/slog"
+	"math/rand"
+	"time"
+)
+
+// RetryPolicy configures the retries of statements which fail with errors
+// like deadlocks or lock wait timeouts. Only single statements executed in
+// auto-commit mode outside of a transaction are retried, since the server
+// has rolled back all of their effects.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of executions of a statement,
+	// including the first one. Statements are not retried if it is less
+	// than 2.
+	MaxAttempts int
+	// Backoff returns the delay before the given retry, starting at 1.
+	// If nil, ExponentialBackoff(10*time.Millisecond, time.Second) is used.
+	Backoff func(retry int) time.Duration
+	// Retryable reports whether a statement which failed with err is
+	// retried. If nil, IsRetryable is used.
+	Retryable func(err error) bool
+}
+
+// ExponentialBackoff returns a RetryPolicy.Backoff which doubles the delay
+// with each retry, starting at base and limited to max. The delays are
+// randomized between half and the full value, so that statements which
+// deadlocked each other are not retried at the same time.
+func ExponentialBackoff(base, max time.Duration) func(retry int) time.Duration {
+	return func(retry int) time.Duration {
+		d := max
+		if retry < 32 && base<<(retry-1) < max {
+			d = base << (retry - 1)
+		}
+		if d <= 0 {
+			return 0
+		}
+		return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+	}
+}
+
+var defaultBackoff = ExponentialBackoff(10*time.Millisecond, time.Second)
+
+// retry calls exec and calls it again according to the RetryPolicy while it
+// fails. A statement is only retried if it runs in auto-commit mode outside
+// of a transaction and the delay before the retry ends before the deadline
+// of ctx. multiStatements is true for text queries which may consist of
+// several statements; these are not retried since some of them may have been
+// committed already.
+func (mc *mysqlConn) retry(ctx context.Context, multiStatements bool, exec func() error) error {
+	p := mc.cfg.retryPolicy
+	if p == nil || p.MaxAttempts < 2 || multiStatements || mc.inTx || mc.xaState != xaNone ||
+		mc.status&statusInTrans != 0 || mc.status&statusInAutocommit == 0 {
+		return exec()
+	}
+
+	retryable, backoff := p.Retryable, p.Backoff
+	if retryable == nil {
+		retryable = IsRetryable
+	}
+	if backoff == nil {
+		backoff = defaultBackoff
+	}
+
+	for retry := 1; ; retry++ {
+		err := exec()
+		if err == nil || retry >= p.MaxAttempts || !retryable(err) || mc.closed.Load() {
+			return err
+		}
+
+		delay := backoff(retry)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return err
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		mc.logEvent(slog.LevelDebug, "retrying statement", err, slog.Int("retry", retry))
+	}
+}
@@ -0,0 +1,143 @@
+// Go MySQL Driver - A MySQL-Driver for Go's database/sql package
+//
+// Copyright 2024 The Go-MySQL-Driver Authors. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package mysql
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"testing"
+	"time"
+)
+
+var (
+	deadlockPacket = append([]byte{iERR, 0xbd, 0x04, '#', '4', '0', '0', '0', '1'}, "Deadlock found"...)
+	lockWaitPacket = append([]byte{iERR, 0xb5, 0x04, '#', 'H', 'Y', '0', '0', '0'}, "Lock wait timeout exceeded"...)
+)
+
+func newRetryMockConn(p RetryPolicy) (*mockConn, *mysqlConn) {
+	conn, mc := newRWMockConn(0)
+	if p.Backoff == nil {
+		p.Backoff = func(int) time.Duration { return 0 }
+	}
+	mc.cfg.retryPolicy = &p
+	mc.status = statusInAutocommit
+	return conn, mc
+}
+
+func TestRetryDeadlock(t *testing.T) {
+	conn, mc := newRetryMockConn(RetryPolicy{MaxAttempts: 3})
+	conn.queuedReplies = [][]byte{
+		makePackets(1, deadlockPacket),
+		makePackets(1, deadlockPacket),
+		makePackets(1, []byte{iOK, 0x01, 0x00, 0x02, 0x00, 0x00, 0x00}),
+	}
+
+	res, err := mc.ExecContext(context.Background(), "UPDATE t SET a = 1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, _ := res.RowsAffected(); n != 1 {
+		t.Errorf("expected 1 row affected, got %d", n)
+	}
+	if n := bytes.Count(conn.written, []byte("UPDATE t")); n != 3 {
+		t.Errorf("expected 3 attempts, got %d", n)
+	}
+}
+
+func TestRetryMaxAttempts(t *testing.T) {
+	conn, mc := newRetryMockConn(RetryPolicy{MaxAttempts: 2})
+	conn.queuedReplies = [][]byte{
+		makePackets(1, deadlockPacket),
+		makePackets(1, deadlockPacket),
+	}
+
+	_, err := mc.QueryContext(context.Background(), "SELECT a FROM t FOR UPDATE", nil)
+	if !IsDeadlock(err) {
+		t.Fatalf("expected a deadlock, got %v", err)
+	}
+	if n := bytes.Count(conn.written, []byte("SELECT a")); n != 2 {
+		t.Errorf("expected 2 attempts, got %d", n)
+	}
+}
+
+func TestRetryNotInTransaction(t *testing.T) {
+	conn, mc := newRetryMockConn(RetryPolicy{MaxAttempts: 3})
+	conn.queuedReplies = [][]byte{
+		makePackets(1, okPacket), // START TRANSACTION
+		makePackets(1, deadlockPacket),
+	}
+
+	ctx := context.Background()
+	if _, err := mc.BeginTx(ctx, driver.TxOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	// The server rolled back the transaction and reports auto-commit mode again
+	mc.status = statusInAutocommit
+	if _, err := mc.ExecContext(ctx, "UPDATE t SET a = 1", nil); !IsDeadlock(err) {
+		t.Fatalf("expected a deadlock, got %v", err)
+	}
+	if n := bytes.Count(conn.written, []byte("UPDATE t")); n != 1 {
+		t.Errorf("expected 1 attempt, got %d", n)
+	}
+
+	// a transaction started by a statement
+	mc.inTx = false
+	mc.status = statusInAutocommit | statusInTrans
+	conn.queuedReplies = [][]byte{makePackets(1, deadlockPacket)}
+	if _, err := mc.ExecContext(ctx, "DELETE FROM t", nil); !IsDeadlock(err) {
+		t.Fatalf("expected a deadlock, got %v", err)
+	}
+}
+
+func TestRetryDeadline(t *testing.T) {
+	conn, mc := newRetryMockConn(RetryPolicy{
+		MaxAttempts: 3,
+		Backoff:     func(int) time.Duration { return time.Hour },
+	})
+	conn.queuedReplies = [][]byte{makePackets(1, deadlockPacket)}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	start := time.Now()
+	if _, err := mc.ExecContext(ctx, "UPDATE t SET a = 1", nil); !IsDeadlock(err) {
+		t.Fatalf("expected a deadlock, got %v", err)
+	}
+	if d := time.Since(start); d > time.Second {
+		t.Errorf("waited %v for a retry after the deadline", d)
+	}
+}
+
+func TestRetryStatement(t *testing.T) {
+	var retried []error
+	conn, mc := newRetryMockConn(RetryPolicy{
+		MaxAttempts: 2,
+		Retryable: func(err error) bool {
+			retried = append(retried, err)
+			return IsLockWaitTimeout(err)
+		},
+	})
+	conn.queuedReplies = [][]byte{
+		makePackets(1, []byte{iOK, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}), // prepare OK, statement 1
+		makePackets(1, lockWaitPacket),
+		makePackets(1, okPacket),
+	}
+
+	ctx := context.Background()
+	stmt, err := mc.PrepareContext(ctx, "UPDATE t SET a = 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stmt.(*mysqlStmt).ExecContext(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(retried) != 1 || !IsLockWaitTimeout(retried[0]) {
+		t.Errorf("expected the predicate to be called for the lock wait timeout, got %v", retried)
+	}
+}
@@ -20,20 +20,61 @@ type resultSet struct {
 	done        bool
 }
 
+// Rows, driver.Rows üzerinden erişilemeyen verileri ortaya çıkarır.
+//
+// Bu, sql.Conn.Raw() kullanılarak ve döndürülen satırların aşağıya dökümü yapılarak erişilebilir:
+//
+//	rows, err := rawConn.QueryContext(...)
+//	rows.(mysql.Rows).WarningCount()
+type Rows interface {
+	driver.Rows
+	// WarningCount, tüm satırlar okunduktan sonra son sonuç kümesinin uyarı sayısını döndürür.
+	WarningCount() uint16
+	// Warnings, fetchWarnings etkinse SHOW WARNINGS ile okunan uyarıları döndürür.
+	Warnings() []Warning
+}
+
 type mysqlRows struct {
-	mc     *mysqlConn
-	rs     resultSet
-	finish func()
+	mc           *mysqlConn
+	rs           resultSet
+	finish       func()
+	warningCount uint16    // son EOF paketinin uyarı sayısı
+	warnings     []Warning // fetchWarnings ile okunan uyarılar
+	noWarnings   bool      // SHOW WARNINGS'in kendi satırları, uyarıları tekrar okunmaz
 }
 
 type binaryRows struct {
 	mysqlRows
+	cursor  *cursor // sunucu tarafı imleç açıldıysa ayarlanır
+	pending []byte  // imleç açılmadıysa önceden okunan ilk satır
 }
 
 type textRows struct {
 	mysqlRows
 }
 
+func (rows *mysqlRows) WarningCount() uint16 {
+	return rows.warningCount
+}
+
+func (rows *mysqlRows) Warnings() []Warning {
+	return append([]Warning(nil), rows.warnings...)
+}
+
+// readWarnings, sonuç kümesinin EOF paketinden sonra uyarı sayısını saklar.
+// fetchWarnings veya warningsAsErrors etkinse ve başka sonuç kümesi yoksa
+// uyarılar SHOW WARNINGS ile okunur.
+func (rows *mysqlRows) readWarnings(mc *mysqlConn) (err error) {
+	if rows.noWarnings {
+		return nil
+	}
+	rows.warningCount = mc.warningCount
+	if mc.status&statusMoreResultsExists == 0 {
+		rows.warnings, err = mc.checkWarnings(rows.warningCount)
+	}
+	return err
+}
+
 func (rows *mysqlRows) Columns() []string {
 	if rows.rs.columnNames != nil {
 		return rows.rs.columnNames
@@ -174,7 +215,41 @@ func (rows *mysqlRows) nextNotEmptyResultSet() (int, error) {
 	}
 }
 
+func (rows *binaryRows) Close() error {
+	if rows.cursor == nil {
+		return rows.mysqlRows.Close()
+	}
+
+	// İmleç satırları arasında bağlantıda okunmamış paket yoktur;
+	// yalnızca sonuna kadar okunmamış imleci sunucuda kapat.
+	if f := rows.finish; f != nil {
+		f()
+		rows.finish = nil
+	}
+	mc := rows.mc
+	if mc == nil {
+		return nil
+	}
+	rows.mc = nil
+	if err := mc.error(); err != nil {
+		return err
+	}
+	return rows.cursor.close(mc)
+}
+
+func (rows *binaryRows) HasNextResultSet() bool {
+	if rows.cursor != nil {
+		// İmleçler yalnızca tek bir sonuç kümesi döndürür
+		return false
+	}
+	return rows.mysqlRows.HasNextResultSet()
+}
+
 func (rows *binaryRows) NextResultSet() error {
+	if rows.cursor != nil {
+		return io.EOF
+	}
+
 	resLen, err := rows.nextNotEmptyResultSet()
 	if err != nil {
 		return err
@@ -190,6 +265,11 @@ func (rows *binaryRows) Next(dest []driver.Value) error {
 			return err
 		}
 
+		// İmleçten sonraki satırı al, gerekirse yeni bir grup iste
+		if rows.cursor != nil {
+			return rows.nextCursorRow(dest)
+		}
+
 		// Akıştan bir sonraki satırı al
 		return rows.readRow(dest)
 	}
@@ -0,0 +1,193 @@
+// Go MySQL Driver - A MySQL-Driver for Go's database/sql package
+//
+// Copyright 2024 The Go-MySQL-Driver Authors. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package mysql
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash"
+	"strconv"
+	"strings"
+)
+
+// SASL mechanisms of authentication_ldap_sasl_client
+const (
+	saslMechanismSCRAMSHA1   = "SCRAM-SHA-1"
+	saslMechanismSCRAMSHA256 = "SCRAM-SHA-256"
+)
+
+// saslAuthPlugin implements the SCRAM mechanisms (RFC 5802, RFC 7677) of the
+// authentication_ldap_sasl_client plugin. The server names the mechanism in
+// the auth plugin data of its auth switch request.
+// https://dev.mysql.com/doc/refman/8.0/en/ldap-pluggable-authentication.html
+type saslAuthPlugin struct{}
+
+func (saslAuthPlugin) Begin(scramble []byte, cfg *Config) (AuthExchange, []byte, error) {
+	var newHash func() hash.Hash
+	switch mechanism := string(bytes.TrimRight(scramble, "\x00")); mechanism {
+	case saslMechanismSCRAMSHA1:
+		newHash = sha1.New
+	case saslMechanismSCRAMSHA256:
+		newHash = sha256.New
+	default:
+		return nil, nil, fmt.Errorf("unsupported SASL mechanism: %q", mechanism)
+	}
+
+	nonce, err := scramNonce()
+	if err != nil {
+		return nil, nil, err
+	}
+	s := &scramExchange{
+		newHash:     newHash,
+		password:    cfg.Passwd,
+		clientNonce: nonce,
+	}
+	s.clientFirstBare = "n=" + scramEscape(cfg.User) + ",r=" + nonce
+
+	// client-first-message: GS2 header without channel binding
+	return s, []byte("n,," + s.clientFirstBare), nil
+}
+
+// scramExchange is the state of a SCRAM authentication.
+type scramExchange struct {
+	newHash         func() hash.Hash
+	password        string
+	clientNonce     string
+	clientFirstBare string
+	serverSignature []byte // expected in server-final-message
+	verified        bool   // set when the server signature has been verified
+}
+
+func (s *scramExchange) Next(data []byte) ([]byte, error) {
+	if s.serverSignature == nil {
+		return s.clientFinal(string(data))
+	}
+	return nil, s.verifyServerFinal(string(data))
+}
+
+// Done fails the authentication if the server accepted it without sending
+// its signature, since the server is then not authenticated.
+func (s *scramExchange) Done() error {
+	if !s.verified {
+		return errors.New("SCRAM: server accepted the authentication without server signature")
+	}
+	return nil
+}
+
+// clientFinal returns the client-final-message for the server-first-message.
+func (s *scramExchange) clientFinal(serverFirst string) ([]byte, error) {
+	attrs, err := scramParse(serverFirst)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := attrs['r']
+	if !strings.HasPrefix(nonce, s.clientNonce) || len(nonce) == len(s.clientNonce) {
+		return nil, errors.New("SCRAM: invalid server nonce")
+	}
+	salt, err := base64.StdEncoding.DecodeString(attrs['s'])
+	if err != nil || len(salt) == 0 {
+		return nil, errors.New("SCRAM: invalid salt")
+	}
+	iterations, err := strconv.Atoi(attrs['i'])
+	if err != nil || iterations < 1 {
+		return nil, errors.New("SCRAM: invalid iteration count")
+	}
+
+	// "biws" is the base64 encoded GS2 header "n,,"
+	clientFinalBare := "c=biws,r=" + nonce
+	authMessage := []byte(s.clientFirstBare + "," + serverFirst + "," + clientFinalBare)
+
+	saltedPassword := scramHi(s.newHash, []byte(s.password), salt, iterations)
+	clientKey := scramHMAC(s.newHash, saltedPassword, []byte("Client Key"))
+	h := s.newHash()
+	h.Write(clientKey)
+	storedKey := h.Sum(nil)
+	clientSignature := scramHMAC(s.newHash, storedKey, authMessage)
+	proof := make([]byte, len(clientKey))
+	subtle.XORBytes(proof, clientKey, clientSignature)
+
+	serverKey := scramHMAC(s.newHash, saltedPassword, []byte("Server Key"))
+	s.serverSignature = scramHMAC(s.newHash, serverKey, authMessage)
+
+	return []byte(clientFinalBare + ",p=" + base64.StdEncoding.EncodeToString(proof)), nil
+}
+
+// verifyServerFinal checks the server signature of the server-final-message.
+func (s *scramExchange) verifyServerFinal(serverFinal string) error {
+	attrs, err := scramParse(serverFinal)
+	if err != nil {
+		return err
+	}
+	if e, ok := attrs['e']; ok {
+		return fmt.Errorf("SCRAM: server error: %s", e)
+	}
+	signature, err := base64.StdEncoding.DecodeString(attrs['v'])
+	if err != nil || !hmac.Equal(signature, s.serverSignature) {
+		return errors.New("SCRAM: invalid server signature")
+	}
+	s.verified = true
+	return nil
+}
+
+// scramNonce returns a random printable nonce.
+func scramNonce() (string, error) {
+	var b [18]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b[:]), nil
+}
+
+// scramEscape encodes the characters ',' and '=' of a username.
+func scramEscape(s string) string {
+	return strings.NewReplacer("=", "=3D", ",", "=2C").Replace(s)
+}
+
+// scramParse splits a SCRAM message into its attributes.
+func scramParse(msg string) (map[byte]string, error) {
+	attrs := make(map[byte]string)
+	for _, attr := range strings.Split(msg, ",") {
+		if len(attr) < 2 || attr[1] != '=' {
+			return nil, fmt.Errorf("SCRAM: malformed message: %q", msg)
+		}
+		attrs[attr[0]] = attr[2:]
+	}
+	return attrs, nil
+}
+
+func scramHMAC(newHash func() hash.Hash, key, data []byte) []byte {
+	mac := hmac.New(newHash, key)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+// scramHi is PBKDF2 with HMAC as pseudorandom function and an output length
+// of one hash.
+func scramHi(newHash func() hash.Hash, password, salt []byte, iterations int) []byte {
+	mac := hmac.New(newHash, password)
+	mac.Write(salt)
+	mac.Write([]byte{0, 0, 0, 1})
+	u := mac.Sum(nil)
+	result := append([]byte(nil), u...)
+	for i := 1; i < iterations; i++ {
+		mac.Reset()
+		mac.Write(u)
+		u = mac.Sum(u[:0])
+		subtle.XORBytes(result, result, u)
+	}
+	return result
+}
@@ -0,0 +1,227 @@
+// Go MySQL Driver - A MySQL-Driver for Go's database/sql package
+//
+// Copyright 2024 The Go-MySQL-Driver Authors. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package mysql
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"hash"
+	"io"
+	"net"
+	"strings"
+	"testing"
+)
+
+// RFC 5802 and RFC 7677 test vectors
+func TestSCRAMVectors(t *testing.T) {
+	vectors := []struct {
+		newHash     func() hash.Hash
+		clientNonce string
+		serverFirst string
+		clientFinal string
+		serverFinal string
+	}{{
+		sha1.New,
+		"fyko+d2lbbFgONRv9qkxdawL",
+		"r=fyko+d2lbbFgONRv9qkxdawL3rfcNHYJY1ZVvWVs7j,s=QSXCR+Q6sek8bf92,i=4096",
+		"c=biws,r=fyko+d2lbbFgONRv9qkxdawL3rfcNHYJY1ZVvWVs7j,p=v0X8v3Bz2T0CJGbJQyF0X+HI4Ts=",
+		"v=rmF9pqV8S7suAoZWja4dJRkFsKQ=",
+	}, {
+		sha256.New,
+		"rOprNGfwEbeRWgbNEkqO",
+		"r=rOprNGfwEbeRWgbNEkqO%hvYDpWUa2RaTCAfuxFIlj)hNlF$k0,s=W22ZaJ0SNY7soEsUEjb6gQ==,i=4096",
+		"c=biws,r=rOprNGfwEbeRWgbNEkqO%hvYDpWUa2RaTCAfuxFIlj)hNlF$k0,p=dHzbZapWIk4jUhN+Ute9ytag9zjfMHgsqmmiz7AndVQ=",
+		"v=6rriTRBi23WpRR/wtup+mMhUZUn/dB5nLTJRsjl95G4=",
+	}}
+
+	for _, v := range vectors {
+		s := &scramExchange{
+			newHash:         v.newHash,
+			password:        "pencil",
+			clientNonce:     v.clientNonce,
+			clientFirstBare: "n=user,r=" + v.clientNonce,
+		}
+		clientFinal, err := s.Next([]byte(v.serverFirst))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(clientFinal) != v.clientFinal {
+			t.Errorf("expected %q, got %q", v.clientFinal, clientFinal)
+		}
+		resp, err := s.Next([]byte(v.serverFinal))
+		if err != nil || resp != nil {
+			t.Errorf("server-final-message not accepted: %v, %q", err, resp)
+		}
+	}
+}
+
+func TestSCRAMRejectsServer(t *testing.T) {
+	begin := func() *scramExchange {
+		exchange, _, err := saslAuthPlugin{}.Begin([]byte(saslMechanismSCRAMSHA256), &Config{User: "user", Passwd: "pencil"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return exchange.(*scramExchange)
+	}
+
+	// the server nonce must extend the client nonce
+	s := begin()
+	if _, err := s.Next([]byte("r=other,s=QSXCR+Q6sek8bf92,i=4096")); err == nil {
+		t.Error("expected error for invalid nonce")
+	}
+
+	s = begin()
+	if _, err := s.Next([]byte("r=" + s.clientNonce + "x,s=QSXCR+Q6sek8bf92,i=0")); err == nil {
+		t.Error("expected error for invalid iteration count")
+	}
+
+	s = begin()
+	if _, err := s.Next([]byte("r=" + s.clientNonce + "x,s=QSXCR+Q6sek8bf92,i=1")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Next([]byte("v=rmF9pqV8S7suAoZWja4dJRkFsKQ=")); err == nil {
+		t.Error("expected error for invalid server signature")
+	}
+
+	if _, _, err := (saslAuthPlugin{}).Begin([]byte("GSSAPI"), &Config{}); err == nil {
+		t.Error("expected error for unsupported mechanism")
+	}
+}
+
+func TestSCRAMEscapeUser(t *testing.T) {
+	_, resp, err := saslAuthPlugin{}.Begin([]byte(saslMechanismSCRAMSHA1+"\x00"), &Config{User: "a=b,c"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(resp), "n,,n=a=3Db=2Cc,r=") {
+		t.Errorf("unexpected client-first-message %q", resp)
+	}
+}
+
+// fakeSCRAMServer authenticates a single client with SCRAM-SHA-256 and the
+// password "pencil" over conn. With skipFinal, it accepts the client without
+// sending the server-final-message.
+func fakeSCRAMServer(conn net.Conn, skipFinal bool, done chan<- error) {
+	defer conn.Close()
+	var sequence byte = 2
+	write := func(payload []byte) error {
+		header := []byte{byte(len(payload)), byte(len(payload) >> 8), byte(len(payload) >> 16), sequence}
+		sequence++
+		_, err := conn.Write(append(header, payload...))
+		return err
+	}
+	read := func() (string, error) {
+		var header [4]byte
+		if _, err := io.ReadFull(conn, header[:]); err != nil {
+			return "", err
+		}
+		payload := make([]byte, int(header[0])|int(header[1])<<8|int(header[2])<<16)
+		if _, err := io.ReadFull(conn, payload); err != nil {
+			return "", err
+		}
+		sequence = header[3] + 1
+		return string(payload), nil
+	}
+
+	done <- func() error {
+		authSwitch := append([]byte{iEOF}, "authentication_ldap_sasl_client\x00"...)
+		if err := write(append(authSwitch, saslMechanismSCRAMSHA256...)); err != nil {
+			return err
+		}
+
+		clientFirst, err := read()
+		if err != nil {
+			return err
+		}
+		if !strings.HasPrefix(clientFirst, "n,,") {
+			return errors.New("missing GS2 header")
+		}
+		clientFirstBare := clientFirst[3:]
+		attrs, err := scramParse(clientFirstBare)
+		if err != nil {
+			return err
+		}
+		salt := []byte("fake salt")
+		serverFirst := "r=" + attrs['r'] + "server,s=" + base64.StdEncoding.EncodeToString(salt) + ",i=4096"
+		if err := write(append([]byte{iAuthMoreData}, serverFirst...)); err != nil {
+			return err
+		}
+
+		clientFinal, err := read()
+		if err != nil {
+			return err
+		}
+		i := strings.LastIndex(clientFinal, ",p=")
+		proof, _ := base64.StdEncoding.DecodeString(clientFinal[i+3:])
+		authMessage := []byte(clientFirstBare + "," + serverFirst + "," + clientFinal[:i])
+
+		// verify the proof with the stored key
+		saltedPassword := scramHi(sha256.New, []byte("pencil"), salt, 4096)
+		storedKey := sha256.Sum256(scramHMAC(sha256.New, saltedPassword, []byte("Client Key")))
+		clientKey := scramHMAC(sha256.New, storedKey[:], authMessage)
+		for i := range clientKey {
+			clientKey[i] ^= proof[i]
+		}
+		if key := sha256.Sum256(clientKey); !hmac.Equal(key[:], storedKey[:]) {
+			return write([]byte{iERR, 0x15, 0x04, '#', '2', '8', '0', '0', '0', 'd', 'e', 'n', 'i', 'e', 'd'})
+		}
+
+		if skipFinal {
+			return write([]byte{iOK, 0x00, 0x00, 0x02, 0x00, 0x00, 0x00})
+		}
+		serverSignature := scramHMAC(sha256.New, scramHMAC(sha256.New, saltedPassword, []byte("Server Key")), authMessage)
+		serverFinal := "v=" + base64.StdEncoding.EncodeToString(serverSignature)
+		if err := write(append([]byte{iAuthMoreData}, serverFinal...)); err != nil {
+			return err
+		}
+		return write([]byte{iOK, 0x00, 0x00, 0x02, 0x00, 0x00, 0x00})
+	}()
+}
+
+func testSCRAMFakeServer(t *testing.T, password string, skipFinal bool) error {
+	client, server := net.Pipe()
+	defer client.Close()
+	done := make(chan error, 1)
+	go fakeSCRAMServer(server, skipFinal, done)
+
+	_, mc := newRWMockConn(2)
+	mc.netConn = client
+	mc.buf = newBuffer(client)
+	mc.cfg.User = "user"
+	mc.cfg.Passwd = password
+
+	err := mc.handleAuthResult(make([]byte, 20), "caching_sha2_password")
+	if serverErr := <-done; serverErr != nil {
+		t.Fatalf("server: %v", serverErr)
+	}
+	return err
+}
+
+func TestSCRAMFakeServer(t *testing.T) {
+	if err := testSCRAMFakeServer(t, "pencil", false); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSCRAMFakeServerWrongPassword(t *testing.T) {
+	err := testSCRAMFakeServer(t, "wrong", false)
+	var mysqlErr *MySQLError
+	if !errors.As(err, &mysqlErr) || mysqlErr.Number != 1045 {
+		t.Fatalf("expected error 1045, got %v", err)
+	}
+}
+
+func TestSCRAMFakeServerWithoutSignature(t *testing.T) {
+	if err := testSCRAMFakeServer(t, "pencil", true); err == nil {
+		t.Fatal("expected error for a server which did not send its signature")
+	}
+}
@@ -0,0 +1,143 @@
+// Go MySQL Driver - A MySQL-Driver for Go's database/sql package
+//
+// Copyright 2024 The Go-MySQL-Driver Authors. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package mysql
+
+// SessionState is the state of a session as reported by the server through
+// session state tracking (CLIENT_SESSION_TRACK, MySQL 5.7+).
+//
+// The server only reports what its session_track_* system variables enable.
+// For example, add session_track_gtids=OWN_GTID and
+// session_track_transaction_info=STATE to the DSN to track GTIDs and the
+// transaction state.
+type SessionState struct {
+	// Schema is the current default schema (session_track_schema).
+	Schema string
+	// SystemVariables holds the last reported value of each changed system
+	// variable listed in session_track_system_variables.
+	SystemVariables map[string]string
+	// GTIDs is the GTID set last reported by the server, usually the GTID of
+	// the last committed transaction (session_track_gtids).
+	GTIDs string
+	// TransactionState is the last reported transaction state, an 8
+	// character string such as "T_______" (session_track_transaction_info).
+	TransactionState string
+	// TransactionCharacteristics is the last reported statement which
+	// restores the characteristics of the current transaction, e.g.
+	// "START TRANSACTION READ ONLY;" (session_track_transaction_info=CHARACTERISTICS).
+	TransactionCharacteristics string
+	// StateChanged is true if the last statement changed the session state
+	// (session_track_state_change).
+	StateChanged bool
+	// InTransaction is true if a transaction is active. It is taken from the
+	// status flags and does not require any tracker.
+	InTransaction bool
+}
+
+// Session state information types
+// https://dev.mysql.com/doc/dev/mysql-server/latest/mysql__com_8h.html#a5bd8f1e1a6ebe1fd4f7fc7b24e4ae1f4
+const (
+	sessionTrackSystemVariables byte = iota
+	sessionTrackSchema
+	sessionTrackStateChange
+	sessionTrackGTIDs
+	sessionTrackTransactionCharacteristics
+	sessionTrackTransactionState
+)
+
+// SessionState returns a copy of the session state last reported by the
+// server.
+func (mc *mysqlConn) SessionState() SessionState {
+	state := mc.session
+	if mc.session.SystemVariables != nil {
+		state.SystemVariables = make(map[string]string, len(mc.session.SystemVariables))
+		for k, v := range mc.session.SystemVariables {
+			state.SystemVariables[k] = v
+		}
+	}
+	state.InTransaction = mc.status&statusInTrans != 0
+	return state
+}
+
+// handleSessionStateChanges updates mc.session from the session state
+// information of an OK packet.
+// https://dev.mysql.com/doc/dev/mysql-server/latest/page_protocol_basic_ok_packet.html
+func (mc *mysqlConn) handleSessionStateChanges(data []byte) error {
+	mc.session.StateChanged = false
+
+	for len(data) > 0 {
+		// type [1 byte]
+		typ := data[0]
+
+		// data [length encoded string]
+		entry, _, n, err := readLengthEncodedString(data[1:])
+		if err != nil {
+			return err
+		}
+		data = data[1+n:]
+
+		switch typ {
+		case sessionTrackSystemVariables:
+			name, _, n, err := readLengthEncodedString(entry)
+			if err != nil {
+				return err
+			}
+			value, _, _, err := readLengthEncodedString(entry[n:])
+			if err != nil {
+				return err
+			}
+			if mc.session.SystemVariables == nil {
+				mc.session.SystemVariables = make(map[string]string)
+			}
+			mc.session.SystemVariables[string(name)] = string(value)
+
+		case sessionTrackSchema:
+			schema, _, _, err := readLengthEncodedString(entry)
+			if err != nil {
+				return err
+			}
+			mc.session.Schema = string(schema)
+
+		case sessionTrackStateChange:
+			changed, _, _, err := readLengthEncodedString(entry)
+			if err != nil {
+				return err
+			}
+			mc.session.StateChanged = string(changed) == "1"
+
+		case sessionTrackGTIDs:
+			// encoding specification [1 byte], only 0 is defined
+			if len(entry) < 1 {
+				return ErrMalformPkt
+			}
+			gtids, _, _, err := readLengthEncodedString(entry[1:])
+			if err != nil {
+				return err
+			}
+			mc.session.GTIDs = string(gtids)
+
+		case sessionTrackTransactionCharacteristics:
+			chars, _, _, err := readLengthEncodedString(entry)
+			if err != nil {
+				return err
+			}
+			mc.session.TransactionCharacteristics = string(chars)
+
+		case sessionTrackTransactionState:
+			state, _, _, err := readLengthEncodedString(entry)
+			if err != nil {
+				return err
+			}
+			mc.session.TransactionState = string(state)
+
+		default:
+			// unknown trackers are skipped, their data is length encoded
+		}
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+// Go MySQL Driver - A MySQL-Driver for Go's database/sql package
+//
+// Copyright 2024 The Go-MySQL-Driver Authors. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package mysql
+
+import (
+	"reflect"
+	"testing"
+)
+
+func appendSessionTracker(b []byte, typ byte, entry []byte) []byte {
+	b = append(b, typ)
+	return appendLengthEncodedString(b, string(entry))
+}
+
+func makeSessionTrackOK(status statusFlag, info string, changes []byte) []byte {
+	data := []byte{iOK, 0x01, 0x00, byte(status), byte(status >> 8), 0x00, 0x00}
+	data = appendLengthEncodedString(data, info)
+	if status&statusSessionStateChanged != 0 {
+		data = appendLengthEncodedString(data, string(changes))
+	}
+	return data
+}
+
+func TestHandleOkPacketSessionTrack(t *testing.T) {
+	_, mc := newRWMockConn(0)
+	mc.flags = clientSessionTrack
+
+	var changes []byte
+	changes = appendSessionTracker(changes, sessionTrackSystemVariables,
+		appendLengthEncodedString(appendLengthEncodedString(nil, "autocommit"), "OFF"))
+	changes = appendSessionTracker(changes, sessionTrackSchema,
+		appendLengthEncodedString(nil, "test"))
+	changes = appendSessionTracker(changes, sessionTrackStateChange,
+		appendLengthEncodedString(nil, "1"))
+	changes = appendSessionTracker(changes, sessionTrackGTIDs,
+		appendLengthEncodedString([]byte{0x00}, "3E11FA47-71CA-11E1-9E33-C80AA9429562:23"))
+	changes = appendSessionTracker(changes, sessionTrackTransactionState,
+		appendLengthEncodedString(nil, "T___W___"))
+	changes = appendSessionTracker(changes, sessionTrackTransactionCharacteristics,
+		appendLengthEncodedString(nil, "START TRANSACTION;"))
+	changes = appendSessionTracker(changes, 0x42, []byte("unknown"))
+
+	status := statusInTrans | statusSessionStateChanged
+	if err := mc.clearResult().handleOkPacket(makeSessionTrackOK(status, "", changes)); err != nil {
+		t.Fatal(err)
+	}
+
+	want := SessionState{
+		Schema:                     "test",
+		SystemVariables:            map[string]string{"autocommit": "OFF"},
+		GTIDs:                      "3E11FA47-71CA-11E1-9E33-C80AA9429562:23",
+		TransactionState:           "T___W___",
+		TransactionCharacteristics: "START TRANSACTION;",
+		StateChanged:               true,
+		InTransaction:              true,
+	}
+	if got := mc.SessionState(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected session state:\ngot  %+v\nwant %+v", got, want)
+	}
+
+	// a later OK packet without changes keeps the state, except for the
+	// per-statement state change flag and the transaction status
+	if err := mc.clearResult().handleOkPacket(makeSessionTrackOK(statusInAutocommit, "info", nil)); err != nil {
+		t.Fatal(err)
+	}
+	var changes2 []byte
+	changes2 = appendSessionTracker(changes2, sessionTrackSchema,
+		appendLengthEncodedString(nil, "other"))
+	if err := mc.clearResult().handleOkPacket(makeSessionTrackOK(statusSessionStateChanged, "", changes2)); err != nil {
+		t.Fatal(err)
+	}
+	want.Schema = "other"
+	want.StateChanged = false
+	want.InTransaction = false
+	if got := mc.SessionState(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected session state:\ngot  %+v\nwant %+v", got, want)
+	}
+}
+
+func TestSessionStateIsCopy(t *testing.T) {
+	_, mc := newRWMockConn(0)
+	mc.session.SystemVariables = map[string]string{"time_zone": "UTC"}
+
+	state := mc.SessionState()
+	state.SystemVariables["time_zone"] = "SYSTEM"
+	if mc.session.SystemVariables["time_zone"] != "UTC" {
+		t.Error("SessionState must return a copy of the system variables")
+	}
+}
+
+func TestHandleOkPacketWithoutSessionTrack(t *testing.T) {
+	_, mc := newRWMockConn(0)
+
+	// info is a string<EOF> which must not be parsed as session state
+	status := statusSessionStateChanged
+	data := []byte{iOK, 0x00, 0x00, byte(status), byte(status >> 8), 0x00, 0x00, 0x05, 'a'}
+	if err := mc.clearResult().handleOkPacket(data); err != nil {
+		t.Fatal(err)
+	}
+	if got := mc.SessionState(); !reflect.DeepEqual(got, SessionState{}) {
+		t.Errorf("expected empty session state, got %+v", got)
+	}
+}
+
+func TestHandleOkPacketMalformedSessionState(t *testing.T) {
+	_, mc := newRWMockConn(0)
+	mc.flags = clientSessionTrack
+
+	changes := []byte{sessionTrackSchema, 0x10, 'x'}
+	err := mc.clearResult().handleOkPacket(makeSessionTrackOK(statusSessionStateChanged, "", changes))
+	if err == nil {
+		t.Fatal("expected error for truncated session state")
+	}
+}
@@ -0,0 +1,151 @@
+// Go MySQL Driver - A MySQL-Driver for Go's database/sql package
+//
+// Copyright 2024 The Go-MySQL-Driver Authors. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package mysql
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math/rand"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// srvNet is the network of addresses which name DNS SRV records, e.g.
+// srv(_mysql._tcp.example.com) or srv(example.com).
+const srvNet = "srv"
+
+// defaultSRVTTL is the time SRV records are cached if SRVTTL is not set.
+const defaultSRVTTL = time.Minute
+
+var errNoSRVRecords = errors.New("no SRV records found")
+
+// SRVResolver looks up DNS SRV records. It is implemented by *net.Resolver.
+type SRVResolver interface {
+	LookupSRV(ctx context.Context, service, proto, name string) (cname string, addrs []*net.SRV, err error)
+}
+
+// srvCache holds the SRV records of a connector until they expire.
+type srvCache struct {
+	mu      sync.Mutex
+	name    string
+	records []*net.SRV
+	expiry  time.Time
+}
+
+// lookup returns the SRV records for the address of cfg. Records are cached
+// for cfg.srvTTL. If the records can not be resolved again after they
+// expired, the expired records are used.
+func (c *srvCache) lookup(ctx context.Context, cfg *Config) ([]*net.SRV, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.name == cfg.Addr && time.Now().Before(c.expiry) {
+		return c.records, nil
+	}
+
+	resolver := cfg.srvResolver
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+	// Plain domain names are looked up as _mysql._tcp.<name>
+	service, proto := "mysql", "tcp"
+	if strings.HasPrefix(cfg.Addr, "_") {
+		service, proto = "", ""
+	}
+	_, records, err := resolver.LookupSRV(ctx, service, proto, cfg.Addr)
+	if err == nil && len(records) == 0 {
+		err = errNoSRVRecords
+	}
+	if err != nil {
+		if c.name == cfg.Addr && len(c.records) > 0 {
+			cfg.logEvent(1, slog.LevelWarn, "resolving SRV records failed, using expired records", err, slog.String("name", cfg.Addr))
+			return c.records, nil
+		}
+		return nil, err
+	}
+
+	ttl := cfg.srvTTL
+	if ttl == 0 {
+		ttl = defaultSRVTTL
+	}
+	c.name = cfg.Addr
+	c.records = records
+	c.expiry = time.Now().Add(ttl)
+	return records, nil
+}
+
+// srvAddrs returns the addresses of the targets of records in the order
+// they should be tried, as described by RFC 2782: by ascending priority and
+// randomly weighted within each priority.
+func srvAddrs(records []*net.SRV) []string {
+	sorted := make([]*net.SRV, 0, len(records))
+	for _, r := range records {
+		// A single record with target "." means the service is not available
+		if r.Target != "." {
+			sorted = append(sorted, r)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Priority < sorted[j].Priority
+	})
+
+	addrs := make([]string, 0, len(sorted))
+	for i := 0; i < len(sorted); {
+		j := i + 1
+		for j < len(sorted) && sorted[j].Priority == sorted[i].Priority {
+			j++
+		}
+		for _, r := range shuffleByWeight(sorted[i:j]) {
+			host := strings.TrimSuffix(r.Target, ".")
+			addrs = append(addrs, net.JoinHostPort(host, strconv.Itoa(int(r.Port))))
+		}
+		i = j
+	}
+	return addrs
+}
+
+// shuffleByWeight orders records of the same priority with the weighted
+// random selection of RFC 2782. Records with weight 0 are put first before
+// the selection, so they have a small chance of being selected early.
+func shuffleByWeight(records []*net.SRV) []*net.SRV {
+	pending := make([]*net.SRV, 0, len(records))
+	total := 0
+	for _, r := range records {
+		if r.Weight == 0 {
+			pending = append(pending, r)
+		}
+	}
+	for _, r := range records {
+		if r.Weight != 0 {
+			pending = append(pending, r)
+		}
+		total += int(r.Weight)
+	}
+
+	ordered := make([]*net.SRV, 0, len(records))
+	for len(pending) > 0 {
+		n := rand.Intn(total + 1)
+		i, sum := 0, 0
+		for ; i < len(pending)-1; i++ {
+			sum += int(pending[i].Weight)
+			if sum >= n {
+				break
+			}
+		}
+		total -= int(pending[i].Weight)
+		ordered = append(ordered, pending[i])
+		pending = append(pending[:i], pending[i+1:]...)
+	}
+	return ordered
+}
@@ -0,0 +1,176 @@
+// Go MySQL Driver - A MySQL-Driver for Go's database/sql package
+//
+// Copyright 2024 The Go-MySQL-Driver Authors. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package mysql
+
+import (
+	"context"
+	"errors"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// stubResolver answers SRV lookups without DNS.
+type stubResolver struct {
+	lookups [][3]string // service, proto and name of each lookup
+	records []*net.SRV
+	err     error
+}
+
+func (r *stubResolver) LookupSRV(ctx context.Context, service, proto, name string) (string, []*net.SRV, error) {
+	r.lookups = append(r.lookups, [3]string{service, proto, name})
+	return "", r.records, r.err
+}
+
+func TestSRVAddrsPriority(t *testing.T) {
+	records := []*net.SRV{
+		{Target: "c.example.com.", Port: 3306, Priority: 20},
+		{Target: "a.example.com.", Port: 3307, Priority: 10},
+		{Target: ".", Port: 0, Priority: 5},
+		{Target: "b.example.com.", Port: 3308, Priority: 30},
+	}
+	want := []string{"a.example.com:3307", "c.example.com:3306", "b.example.com:3308"}
+	if got := srvAddrs(records); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestSRVAddrsWeight(t *testing.T) {
+	records := []*net.SRV{
+		{Target: "light", Port: 3306, Weight: 0},
+		{Target: "heavy", Port: 3306, Weight: 10},
+	}
+
+	// light is selected first only if the random number is 0, i.e. with a
+	// probability of 1/11
+	heavyFirst := 0
+	for i := 0; i < 1000; i++ {
+		addrs := srvAddrs(records)
+		if len(addrs) != 2 {
+			t.Fatalf("expected 2 addresses, got %v", addrs)
+		}
+		if addrs[0] == "heavy:3306" {
+			heavyFirst++
+		}
+	}
+	if heavyFirst < 800 || heavyFirst == 1000 {
+		t.Errorf("heavy was selected first %d of 1000 times", heavyFirst)
+	}
+}
+
+func TestSRVCacheTTL(t *testing.T) {
+	resolver := &stubResolver{records: []*net.SRV{{Target: "db1.", Port: 3306}}}
+	cfg := NewConfig()
+	cfg.Net = "srv"
+	cfg.Addr = "example.com"
+	if err := cfg.Apply(UseSRVResolver(resolver), SRVTTL(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	var cache srvCache
+	for i := 0; i < 2; i++ {
+		if _, err := cache.lookup(context.Background(), cfg); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if want := [][3]string{{"mysql", "tcp", "example.com"}}; !reflect.DeepEqual(resolver.lookups, want) {
+		t.Fatalf("lookups %v, want %v", resolver.lookups, want)
+	}
+
+	// Expired records are resolved again
+	cache.expiry = time.Now()
+	resolver.records = []*net.SRV{{Target: "db2.", Port: 3306}}
+	records, err := cache.lookup(context.Background(), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resolver.lookups) != 2 || records[0].Target != "db2." {
+		t.Fatalf("expected a second lookup returning db2, got %v with %d lookups", records[0].Target, len(resolver.lookups))
+	}
+
+	// If resolving fails, the expired records are used
+	cache.expiry = time.Now()
+	resolver.err = errors.New("SERVFAIL")
+	cfg.Logger = &NopLogger{}
+	records, err = cache.lookup(context.Background(), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if records[0].Target != "db2." {
+		t.Errorf("expected expired record db2, got %v", records[0].Target)
+	}
+}
+
+func TestConnectorSRV(t *testing.T) {
+	resolver := &stubResolver{records: []*net.SRV{
+		{Target: "db2.example.com.", Port: 3307, Priority: 20},
+		{Target: "db1.example.com.", Port: 3306, Priority: 10},
+	}}
+	cfg, err := ParseDSN("root@srv(_mysql._tcp.example.com)/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.Apply(UseSRVResolver(resolver)); err != nil {
+		t.Fatal(err)
+	}
+	var dialed []string
+	cfg.DialFunc = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		dialed = append(dialed, network+":"+addr)
+		if addr == "db1.example.com:3306" {
+			return nil, errors.New("connection refused")
+		}
+		client, server := net.Pipe()
+		go fakeAuthServer(server, "")
+		return client, nil
+	}
+
+	conn, err := newConnector(cfg).Connect(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	if want := [][3]string{{"", "", "_mysql._tcp.example.com"}}; !reflect.DeepEqual(resolver.lookups, want) {
+		t.Errorf("lookups %v, want %v", resolver.lookups, want)
+	}
+	if want := []string{"tcp:db1.example.com:3306", "tcp:db2.example.com:3307"}; !reflect.DeepEqual(dialed, want) {
+		t.Errorf("dialed %v, want %v", dialed, want)
+	}
+}
+
+func TestConnectorSRVNoRecords(t *testing.T) {
+	cfg, err := ParseDSN("root@srv(example.com)/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.Apply(UseSRVResolver(&stubResolver{})); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := newConnector(cfg).Connect(context.Background()); err != errNoSRVRecords {
+		t.Errorf("expected errNoSRVRecords, got %v", err)
+	}
+}
+
+func TestConnectorSRVNotAvailable(t *testing.T) {
+	cfg, err := ParseDSN("root@srv(example.com)/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resolver := &stubResolver{records: []*net.SRV{{Target: ".", Port: 0}}}
+	if err := cfg.Apply(UseSRVResolver(resolver)); err != nil {
+		t.Fatal(err)
+	}
+
+	conn, err := newConnector(cfg).Connect(context.Background())
+	if err != errNoSRVRecords || conn != nil {
+		t.Errorf("expected errNoSRVRecords, got %v, %v", conn, err)
+	}
+}
@@ -9,6 +9,7 @@
 package mysql
 
 import (
+	"context"
 	"database/sql/driver"
 	"encoding/json"
 	"fmt"
@@ -17,9 +18,12 @@ import (
 )
 
 type mysqlStmt struct {
-	mc         *mysqlConn
-	id         uint32
-	paramCount int
+	mc          *mysqlConn
+	id          uint32
+	paramCount  int
+	columnCount int
+	sql         string
+	resets      uint32 // hazırlandığı andaki mc.sessionResets değeri
 }
 
 func (stmt *mysqlStmt) Close() error {
@@ -32,11 +36,38 @@ func (stmt *mysqlStmt) Close() error {
 		return nil
 	}
 
-	err := stmt.mc.writeCommandPacketUint32(comStmtClose, stmt.id)
+	var err error
+	if stmt.resets == stmt.mc.sessionResets {
+		err = stmt.mc.writeCommandPacketUint32(comStmtClose, stmt.id)
+	}
+	// Aksi halde oturum sıfırlaması ifadeyi sunucuda zaten sildi
+	if t := stmt.mc.cfg.tracer; t != nil {
+		t.OnStmtClose(context.Background(), stmt.sql, err)
+	}
 	stmt.mc = nil
 	return err
 }
 
+// prepareAgain, hazırlandıktan sonra oturum sıfırlandıysa ifadeyi yeniden
+// hazırlar, çünkü sıfırlama sunucudaki hazırlanmış ifadeleri siler.
+func (stmt *mysqlStmt) prepareAgain() error {
+	mc := stmt.mc
+	if stmt.resets == mc.sessionResets {
+		return nil
+	}
+
+	ds, err := mc.Prepare(stmt.sql)
+	if err != nil {
+		return err
+	}
+	prepared := ds.(*mysqlStmt)
+	stmt.id = prepared.id
+	stmt.paramCount = prepared.paramCount
+	stmt.columnCount = prepared.columnCount
+	stmt.resets = prepared.resets
+	return nil
+}
+
 func (stmt *mysqlStmt) NumInput() int {
 	return stmt.paramCount
 }
@@ -54,8 +85,11 @@ func (stmt *mysqlStmt) Exec(args []driver.Value) (driver.Result, error) {
 	if stmt.mc.closed.Load() {
 		return nil, driver.ErrBadConn
 	}
+	if err := stmt.prepareAgain(); err != nil {
+		return nil, err
+	}
 	// Komutu gönder
-	err := stmt.writeExecutePacket(args)
+	err := stmt.writeExecutePacket(args, cursorTypeNoCursor)
 	if err != nil {
 		return nil, stmt.mc.markBadConn(err)
 	}
@@ -71,7 +105,7 @@ func (stmt *mysqlStmt) Exec(args []driver.Value) (driver.Result, error) {
 
 	if resLen > 0 {
 		// Sütunlar
-		if err = mc.readUntilEOF(); err != nil {
+		if err = mc.skipColumns(resLen); err != nil {
 			return nil, err
 		}
 
@@ -85,8 +119,7 @@ func (stmt *mysqlStmt) Exec(args []driver.Value) (driver.Result, error) {
 		return nil, err
 	}
 
-	copied := mc.result
-	return &copied, nil
+	return mc.execResult()
 }
 
 func (stmt *mysqlStmt) Query(args []driver.Value) (driver.Rows, error) {
@@ -97,8 +130,18 @@ func (stmt *mysqlStmt) query(args []driver.Value) (*binaryRows, error) {
 	if stmt.mc.closed.Load() {
 		return nil, driver.ErrBadConn
 	}
+	if err := stmt.prepareAgain(); err != nil {
+		return nil, err
+	}
+	// Yalnızca sütun döndüren ifadeler için salt okunur imleç iste
+	fetchSize := stmt.mc.cfg.cursorFetchSize
+	cursorType := cursorTypeNoCursor
+	if fetchSize > 0 && stmt.columnCount > 0 {
+		cursorType = cursorTypeReadOnly
+	}
+
 	// Komutu gönder
-	err := stmt.writeExecutePacket(args)
+	err := stmt.writeExecutePacket(args, cursorType)
 	if err != nil {
 		return nil, stmt.mc.markBadConn(err)
 	}
@@ -117,8 +160,21 @@ func (stmt *mysqlStmt) query(args []driver.Value) (*binaryRows, error) {
 	if resLen > 0 {
 		rows.mc = mc
 		rows.rs.columns, err = mc.readColumns(resLen)
+
+		// CLIENT_DEPRECATE_EOF ile imleç durumu sütunlardan sonra ayrı bir pakette gelir
+		if err == nil && cursorType == cursorTypeReadOnly && mc.flags&clientDeprecateEOF != 0 {
+			err = rows.readCursorStatus()
+		}
+
+		// Sunucu bir imleç açtıysa satırlar COM_STMT_FETCH ile gruplar halinde alınır
+		if err == nil && cursorType == cursorTypeReadOnly && mc.status&statusCursorExists != 0 {
+			rows.cursor = newCursor(stmt, fetchSize)
+		}
 	} else {
 		rows.rs.done = true
+		if err := rows.readWarnings(mc); err != nil {
+			return nil, err
+		}
 
 		switch err := rows.NextResultSet(); err {
 		case nil, io.EOF:
@@ -0,0 +1,124 @@
+// Go MySQL Driver - A MySQL-Driver for Go's database/sql package
+//
+// Copyright 2024 The Go-MySQL-Driver Authors. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package mysql
+
+import (
+	"context"
+	"database/sql/driver"
+	"time"
+)
+
+// ConnectPhase is a phase of establishing a connection.
+type ConnectPhase string
+
+// Phases of establishing a connection.
+const (
+	ConnectPhaseDial ConnectPhase = "dial" // connecting to the server
+	ConnectPhaseTLS  ConnectPhase = "tls"  // TLS handshake during authentication, if TLS is used
+	ConnectPhaseAuth ConnectPhase = "auth" // authentication, including the TLS handshake
+)
+
+// Tracer receives callbacks around driver operations, e.g. to create
+// OpenTelemetry spans. The context returned by a Start callback is passed to
+// the callbacks of the same operation, so spans started there nest
+// correctly. Implementations must be safe for concurrent use by multiple
+// connections. Embed NopTracer to implement only some of the callbacks.
+type Tracer interface {
+	// OnConnectStart is called before a connection to addr is established.
+	// It is called for each host that is tried.
+	OnConnectStart(ctx context.Context, addr string) context.Context
+	// OnConnectPhase is called after a phase of establishing a connection.
+	OnConnectPhase(ctx context.Context, phase ConnectPhase, start, end time.Time, err error)
+	// OnConnectDone is called after the connection is established or failed.
+	OnConnectDone(ctx context.Context, err error)
+
+	// OnQueryStart is called before a query or statement is executed,
+	// including the statements of transactions.
+	OnQueryStart(ctx context.Context, query string, numArgs int) context.Context
+	// OnQueryDone is called once the result of the query has been read.
+	// For queries returning rows, this is before the rows are read.
+	OnQueryDone(ctx context.Context, info QueryDoneInfo)
+
+	// OnPrepare is called after a statement has been prepared.
+	OnPrepare(ctx context.Context, query string, duration time.Duration, err error)
+	// OnStmtClose is called when a prepared statement is closed.
+	OnStmtClose(ctx context.Context, query string, err error)
+}
+
+// QueryDoneInfo describes an executed query for Tracer.OnQueryDone.
+type QueryDoneInfo struct {
+	Query        string
+	NumArgs      int
+	RowsAffected int64 // 0 for queries returning rows
+	Duration     time.Duration
+	Err          error
+}
+
+// NopTracer is a Tracer which does nothing.
+type NopTracer struct{}
+
+// OnConnectStart implements Tracer interface.
+func (NopTracer) OnConnectStart(ctx context.Context, _ string) context.Context { return ctx }
+
+// OnConnectPhase implements Tracer interface.
+func (NopTracer) OnConnectPhase(context.Context, ConnectPhase, time.Time, time.Time, error) {}
+
+// OnConnectDone implements Tracer interface.
+func (NopTracer) OnConnectDone(context.Context, error) {}
+
+// OnQueryStart implements Tracer interface.
+func (NopTracer) OnQueryStart(ctx context.Context, _ string, _ int) context.Context { return ctx }
+
+// OnQueryDone implements Tracer interface.
+func (NopTracer) OnQueryDone(context.Context, QueryDoneInfo) {}
+
+// OnPrepare implements Tracer interface.
+func (NopTracer) OnPrepare(context.Context, string, time.Duration, error) {}
+
+// OnStmtClose implements Tracer interface.
+func (NopTracer) OnStmtClose(context.Context, string, error) {}
+
+// traceQuery calls OnQueryStart of the tracer and returns a function which
+// calls OnQueryDone with the result of the query.
+func (mc *mysqlConn) traceQuery(ctx context.Context, query string, numArgs int) func(res driver.Result, err error) {
+	t := mc.cfg.tracer
+	if t == nil {
+		return func(driver.Result, error) {}
+	}
+
+	start := time.Now()
+	ctx = t.OnQueryStart(ctx, query, numArgs)
+	return func(res driver.Result, err error) {
+		info := QueryDoneInfo{
+			Query:    query,
+			NumArgs:  numArgs,
+			Duration: time.Since(start),
+			Err:      err,
+		}
+		if res != nil {
+			info.RowsAffected, _ = res.RowsAffected()
+		}
+		t.OnQueryDone(ctx, info)
+	}
+}
+
+// traceConnectPhase calls OnConnectPhase of the tracer while the connection
+// is established.
+func (mc *mysqlConn) traceConnectPhase(phase ConnectPhase, start time.Time, err error) {
+	if mc.connectTrace != nil {
+		mc.connectTrace(phase, start, err)
+	}
+}
+
+// tracePrepare calls OnPrepare of the tracer.
+func (mc *mysqlConn) tracePrepare(ctx context.Context, query string, start time.Time, err error) {
+	if t := mc.cfg.tracer; t != nil {
+		t.OnPrepare(ctx, query, time.Since(start), err)
+	}
+}
@@ -0,0 +1,178 @@
+// Go MySQL Driver - A MySQL-Driver for Go's database/sql package
+//
+// Copyright 2024 The Go-MySQL-Driver Authors. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package mysql
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type spanKey struct{}
+
+// recordingTracer records the callbacks. Start callbacks put a span name
+// into the context, which the other callbacks record.
+type recordingTracer struct {
+	events []string
+	done   []QueryDoneInfo
+}
+
+func (r *recordingTracer) span(ctx context.Context) any { return ctx.Value(spanKey{}) }
+
+func (r *recordingTracer) OnConnectStart(ctx context.Context, addr string) context.Context {
+	r.events = append(r.events, "connect start "+addr)
+	return context.WithValue(ctx, spanKey{}, "connect")
+}
+
+func (r *recordingTracer) OnConnectPhase(ctx context.Context, phase ConnectPhase, start, end time.Time, err error) {
+	if end.Before(start) {
+		panic("phase ends before it starts")
+	}
+	r.events = append(r.events, fmt.Sprintf("%v phase %s %v", r.span(ctx), phase, err))
+}
+
+func (r *recordingTracer) OnConnectDone(ctx context.Context, err error) {
+	r.events = append(r.events, fmt.Sprintf("%v done %v", r.span(ctx), err))
+}
+
+func (r *recordingTracer) OnQueryStart(ctx context.Context, query string, numArgs int) context.Context {
+	r.events = append(r.events, fmt.Sprintf("query start %s %d", query, numArgs))
+	return context.WithValue(ctx, spanKey{}, "query")
+}
+
+func (r *recordingTracer) OnQueryDone(ctx context.Context, info QueryDoneInfo) {
+	r.events = append(r.events, fmt.Sprintf("%v done %s", r.span(ctx), info.Query))
+	r.done = append(r.done, info)
+}
+
+func (r *recordingTracer) OnPrepare(ctx context.Context, query string, duration time.Duration, err error) {
+	r.events = append(r.events, fmt.Sprintf("prepare %s %v", query, err))
+}
+
+func (r *recordingTracer) OnStmtClose(ctx context.Context, query string, err error) {
+	r.events = append(r.events, fmt.Sprintf("close %s %v", query, err))
+}
+
+func TestTracerConnect(t *testing.T) {
+	tracer := new(recordingTracer)
+	cfg, err := ParseDSN("root@tcp(db1)/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.DialFunc = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		client, server := net.Pipe()
+		go fakeAuthServer(server, "")
+		return client, nil
+	}
+	if err := cfg.Apply(UseTracer(tracer)); err != nil {
+		t.Fatal(err)
+	}
+
+	conn, err := newConnector(cfg).Connect(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	want := []string{
+		"connect start db1:3306",
+		"connect phase dial <nil>",
+		"connect phase auth <nil>",
+		"connect done <nil>",
+	}
+	if !reflect.DeepEqual(tracer.events, want) {
+		t.Errorf("got events %q, want %q", tracer.events, want)
+	}
+	if conn.(*mysqlConn).connectTrace != nil {
+		t.Error("connect phases are still traced after connecting")
+	}
+}
+
+func TestTracerQueries(t *testing.T) {
+	tracer := new(recordingTracer)
+	conn, mc := newRWMockConn(0)
+	mc.cfg.tracer = tracer
+	conn.queuedReplies = [][]byte{
+		makePackets(1, []byte{iOK, 0x03, 0x00, 0x02, 0x00, 0x00, 0x00}), // 3 rows affected
+		makePackets(1, okPacket), // START TRANSACTION
+		makePackets(1, okPacket), // COMMIT
+	}
+
+	ctx := context.Background()
+	if _, err := mc.ExecContext(ctx, "DELETE FROM t", nil); err != nil {
+		t.Fatal(err)
+	}
+	tx, err := mc.BeginTx(ctx, driver.TxOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Arguments are not traced if database/sql has to prepare the statement
+	if _, err := mc.ExecContext(ctx, "DELETE FROM t WHERE id = ?", []driver.NamedValue{{Ordinal: 1, Value: int64(1)}}); err != driver.ErrSkip {
+		t.Fatalf("expected ErrSkip, got %v", err)
+	}
+
+	want := []string{
+		"query start DELETE FROM t 0",
+		"query done DELETE FROM t",
+		"query start START TRANSACTION 0",
+		"query done START TRANSACTION",
+		"query start COMMIT 0",
+		"query done COMMIT",
+	}
+	if !reflect.DeepEqual(tracer.events, want) {
+		t.Errorf("got events %q, want %q", tracer.events, want)
+	}
+	if tracer.done[0].RowsAffected != 3 {
+		t.Errorf("expected 3 rows affected, got %d", tracer.done[0].RowsAffected)
+	}
+}
+
+func TestTracerStatement(t *testing.T) {
+	tracer := new(recordingTracer)
+	conn, mc := newRWMockConn(0)
+	mc.cfg.tracer = tracer
+	conn.queuedReplies = [][]byte{
+		makePackets(1, []byte{iOK, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}), // prepare OK, statement 1
+		makePackets(1, okPacket), // execute
+		nil,                      // COM_STMT_CLOSE has no reply
+	}
+
+	ctx := context.Background()
+	stmt, err := mc.PrepareContext(ctx, "DO 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stmt.(*mysqlStmt).ExecContext(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := stmt.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := stmt.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"prepare DO 1 <nil>",
+		"query start DO 1 0",
+		"query done DO 1",
+		"close DO 1 <nil>",
+	}
+	if !reflect.DeepEqual(tracer.events, want) {
+		t.Errorf("got events %q, want %q", tracer.events, want)
+	}
+}
@@ -8,15 +8,40 @@
 
 package mysql
 
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"strconv"
+)
+
+var (
+	errNoTransaction   = errors.New("savepoints require an active transaction")
+	errNestedTxOptions = errors.New("nested transactions can not have their own options")
+	errChangeUserInTx  = errors.New("the user can not be changed during a transaction")
+)
+
 type mysqlTx struct {
-	mc *mysqlConn
+	mc        *mysqlConn
+	ctx       context.Context // context of BeginTx, for the tracer
+	savepoint string          // savepoint of a nested transaction, empty for the outermost one
+	restore   string          // restores the session variables changed for TxOptions
 }
 
 func (tx *mysqlTx) Commit() (err error) {
 	if tx.mc == nil || tx.mc.closed.Load() {
 		return ErrInvalidConn
 	}
-	err = tx.mc.exec("COMMIT")
+	if tx.savepoint != "" {
+		err = tx.exec("RELEASE SAVEPOINT " + quoteIdentifier(tx.savepoint))
+		tx.mc.endNested()
+		tx.mc = nil
+		return
+	}
+	err = tx.end("COMMIT")
+	tx.mc.inTx = false
+	tx.mc.nestedTx = 0
 	tx.mc = nil
 	return
 }
@@ -25,7 +50,96 @@ func (tx *mysqlTx) Rollback() (err error) {
 	if tx.mc == nil || tx.mc.closed.Load() {
 		return ErrInvalidConn
 	}
-	err = tx.mc.exec("ROLLBACK")
+	if tx.savepoint != "" {
+		name := quoteIdentifier(tx.savepoint)
+		if err = tx.exec("ROLLBACK TO SAVEPOINT " + name); err == nil {
+			err = tx.exec("RELEASE SAVEPOINT " + name)
+		}
+		tx.mc.endNested()
+		tx.mc = nil
+		return
+	}
+	err = tx.end("ROLLBACK")
+	tx.mc.inTx = false
+	tx.mc.nestedTx = 0
 	tx.mc = nil
 	return
 }
+
+func (tx *mysqlTx) exec(query string) error {
+	done := tx.mc.traceQuery(tx.ctx, query, 0)
+	err := tx.mc.exec(query)
+	done(nil, err)
+	return err
+}
+
+// end commits or rolls back the outermost transaction and restores the
+// session variables in the same round trip.
+func (tx *mysqlTx) end(query string) error {
+	if tx.restore == "" {
+		return tx.exec(query)
+	}
+	done := tx.mc.traceQuery(tx.ctx, query, 0)
+	_, errs, err := tx.mc.execPipeline([]string{query, tx.restore})
+	if err == nil {
+		err = firstError(errs)
+	}
+	done(nil, err)
+	return err
+}
+
+// beginNested emulates a transaction within the active transaction with a
+// savepoint. Committing it releases the savepoint, rolling it back rolls
+// the outer transaction back to the savepoint.
+func (mc *mysqlConn) beginNested(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if sql.IsolationLevel(opts.Isolation) != sql.LevelDefault || opts.ReadOnly {
+		return nil, errNestedTxOptions
+	}
+	tx := &mysqlTx{mc: mc, ctx: ctx, savepoint: "go_mysql_nested_" + strconv.Itoa(mc.nestedTx+1)}
+	if err := tx.exec("SAVEPOINT " + quoteIdentifier(tx.savepoint)); err != nil {
+		return nil, err
+	}
+	mc.nestedTx++
+	return tx, nil
+}
+
+// endNested is called when a nested transaction ends. It may end after the
+// outer transaction, which removed its savepoint already.
+func (mc *mysqlConn) endNested() {
+	if mc.nestedTx > 0 {
+		mc.nestedTx--
+	}
+}
+
+// Savepoint implements Conn interface.
+func (mc *mysqlConn) Savepoint(ctx context.Context, name string) error {
+	return mc.savepointCommand(ctx, "SAVEPOINT "+quoteIdentifier(name))
+}
+
+// RollbackTo implements Conn interface.
+func (mc *mysqlConn) RollbackTo(ctx context.Context, name string) error {
+	return mc.savepointCommand(ctx, "ROLLBACK TO SAVEPOINT "+quoteIdentifier(name))
+}
+
+// Release implements Conn interface.
+func (mc *mysqlConn) Release(ctx context.Context, name string) error {
+	return mc.savepointCommand(ctx, "RELEASE SAVEPOINT "+quoteIdentifier(name))
+}
+
+func (mc *mysqlConn) savepointCommand(ctx context.Context, query string) error {
+	if mc.closed.Load() {
+		return driver.ErrBadConn
+	}
+	if !mc.inTx && mc.status&statusInTrans == 0 {
+		return errNoTransaction
+	}
+	if err := mc.watchCancel(ctx); err != nil {
+		return err
+	}
+	defer mc.finish()
+
+	done := mc.traceQuery(ctx, query, 0)
+	err := mc.exec(query)
+	done(nil, err)
+	return err
+}
@@ -0,0 +1,130 @@
+// Go MySQL Driver - A MySQL-Driver for Go's database/sql package
+//
+// Copyright 2024 The Go-MySQL-Driver Authors. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"reflect"
+	"testing"
+)
+
+func TestSavepoints(t *testing.T) {
+	tracer := new(recordingTracer)
+	conn, mc := newRWMockConn(0)
+	mc.cfg.tracer = tracer
+	conn.queuedReplies = [][]byte{
+		makePackets(1, []byte{iOK, 0x00, 0x00, 0x03, 0x00, 0x00, 0x00}), // START TRANSACTION, in transaction
+		makePackets(1, okPacket),
+		makePackets(1, okPacket),
+		makePackets(1, okPacket),
+		makePackets(1, okPacket),
+	}
+
+	ctx := context.Background()
+	tx, err := mc.BeginTx(ctx, driver.TxOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := mc.Savepoint(ctx, "before`import"); err != nil {
+		t.Fatal(err)
+	}
+	if err := mc.RollbackTo(ctx, "before`import"); err != nil {
+		t.Fatal(err)
+	}
+	if err := mc.Release(ctx, "before`import"); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"query start START TRANSACTION 0",
+		"query done START TRANSACTION",
+		"query start SAVEPOINT `before``import` 0",
+		"query done SAVEPOINT `before``import`",
+		"query start ROLLBACK TO SAVEPOINT `before``import` 0",
+		"query done ROLLBACK TO SAVEPOINT `before``import`",
+		"query start RELEASE SAVEPOINT `before``import` 0",
+		"query done RELEASE SAVEPOINT `before``import`",
+		"query start COMMIT 0",
+		"query done COMMIT",
+	}
+	if !reflect.DeepEqual(tracer.events, want) {
+		t.Errorf("got events %q, want %q", tracer.events, want)
+	}
+
+	mc.status = statusInAutocommit
+	if err := mc.Savepoint(ctx, "a"); err != errNoTransaction {
+		t.Errorf("expected errNoTransaction outside of a transaction, got %v", err)
+	}
+}
+
+func TestNestedTransactions(t *testing.T) {
+	tracer := new(recordingTracer)
+	conn, mc := newRWMockConn(0)
+	mc.cfg.tracer = tracer
+	conn.queuedReplies = [][]byte{
+		makePackets(1, okPacket), // START TRANSACTION
+		makePackets(1, okPacket), // SAVEPOINT 1
+		makePackets(1, okPacket), // SAVEPOINT 2
+		makePackets(1, okPacket), // RELEASE 2
+		makePackets(1, okPacket), // ROLLBACK TO 1
+		makePackets(1, okPacket), // RELEASE 1
+		makePackets(1, okPacket), // COMMIT
+	}
+
+	ctx := context.Background()
+	outer, err := mc.BeginTx(ctx, driver.TxOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	inner, err := mc.BeginTx(ctx, driver.TxOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	innermost, err := mc.BeginTx(ctx, driver.TxOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := mc.BeginTx(ctx, driver.TxOptions{Isolation: driver.IsolationLevel(sql.LevelSerializable)}); err != errNestedTxOptions {
+		t.Errorf("expected errNestedTxOptions, got %v", err)
+	}
+	if err := innermost.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if err := inner.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+	if err := outer.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if mc.inTx || mc.nestedTx != 0 {
+		t.Errorf("transaction state not reset: inTx %v, nestedTx %d", mc.inTx, mc.nestedTx)
+	}
+
+	var queries []string
+	for _, info := range tracer.done {
+		queries = append(queries, info.Query)
+	}
+	want := []string{
+		"START TRANSACTION",
+		"SAVEPOINT `go_mysql_nested_1`",
+		"SAVEPOINT `go_mysql_nested_2`",
+		"RELEASE SAVEPOINT `go_mysql_nested_2`",
+		"ROLLBACK TO SAVEPOINT `go_mysql_nested_1`",
+		"RELEASE SAVEPOINT `go_mysql_nested_1`",
+		"COMMIT",
+	}
+	if !reflect.DeepEqual(queries, want) {
+		t.Errorf("got queries %q, want %q", queries, want)
+	}
+}
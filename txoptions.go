@@ -0,0 +1,162 @@
+// Go MySQL Driver - A MySQL-Driver for Go's database/sql package
+//
+// Copyright 2024 The Go-MySQL-Driver Authors. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	errReadOnlyReadWrite = errors.New("a transaction can not be read-only and read-write")
+	errGTIDWaitTimeout   = errors.New("timeout waiting for the GTID set to be executed")
+)
+
+// TxOptions are MySQL specific options of a transaction, in addition to
+// the isolation level and read-only flag of sql.TxOptions. Attach them to
+// the context passed to BeginTx with WithTxOptions.
+//
+// The statements which start the transaction are sent in a single round
+// trip. Session variables changed for the transaction are restored when it
+// ends.
+type TxOptions struct {
+	// ConsistentSnapshot starts the transaction WITH CONSISTENT SNAPSHOT,
+	// so that InnoDB takes the snapshot for consistent reads immediately
+	// instead of at the first read.
+	ConsistentSnapshot bool
+	// ReadWrite starts the transaction READ WRITE, e.g. if the session
+	// defaults to read-only transactions.
+	ReadWrite bool
+	// LockWaitTimeout overrides innodb_lock_wait_timeout during the
+	// transaction. It is rounded up to whole seconds.
+	LockWaitTimeout time.Duration
+	// WaitForGTIDs waits until the server has applied the transactions of
+	// the GTID set before the transaction starts. Pass the GTIDs of writes
+	// on the source to read them from a replica (causal reads). The
+	// transaction fails if the wait exceeds WaitForGTIDsTimeout, 0 waits
+	// without a timeout.
+	WaitForGTIDs        string
+	WaitForGTIDsTimeout time.Duration
+}
+
+type txOptionsKey struct{}
+
+// WithTxOptions returns a copy of ctx which passes opts to BeginTx.
+//
+//	ctx = mysql.WithTxOptions(ctx, mysql.TxOptions{ConsistentSnapshot: true})
+//	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+func WithTxOptions(ctx context.Context, opts TxOptions) context.Context {
+	return context.WithValue(ctx, txOptionsKey{}, opts)
+}
+
+// txOptions returns the TxOptions attached to ctx.
+func txOptions(ctx context.Context) (TxOptions, bool) {
+	opts, ok := ctx.Value(txOptionsKey{}).(TxOptions)
+	return opts, ok
+}
+
+// beginWithOptions starts a transaction with options which need more than
+// START TRANSACTION. All statements are sent with execPipeline.
+func (mc *mysqlConn) beginWithOptions(ctx context.Context, opts driver.TxOptions, ext TxOptions) (driver.Tx, error) {
+	if opts.ReadOnly && ext.ReadWrite {
+		return nil, errReadOnlyReadWrite
+	}
+
+	var queries []string
+	wait, isolation, lockWait := -1, -1, -1
+	if ext.WaitForGTIDs != "" {
+		if strings.ContainsAny(ext.WaitForGTIDs, `'\`) {
+			return nil, errors.New("invalid GTID set: " + ext.WaitForGTIDs)
+		}
+		q := "SELECT WAIT_FOR_EXECUTED_GTID_SET('" + ext.WaitForGTIDs + "'"
+		if ext.WaitForGTIDsTimeout > 0 {
+			q += ", " + strconv.FormatFloat(ext.WaitForGTIDsTimeout.Seconds(), 'f', -1, 64)
+		}
+		wait, queries = len(queries), append(queries, q+")")
+	}
+	if sql.IsolationLevel(opts.Isolation) != sql.LevelDefault {
+		level, err := mapIsolationLevel(opts.Isolation)
+		if err != nil {
+			return nil, err
+		}
+		isolation, queries = len(queries), append(queries, "SET TRANSACTION ISOLATION LEVEL "+level)
+	}
+	var restore string
+	if ext.LockWaitTimeout > 0 {
+		secs := strconv.FormatInt(int64((ext.LockWaitTimeout+time.Second-1)/time.Second), 10)
+		lockWait, queries = len(queries), append(queries,
+			"SET @go_mysql_lock_wait_timeout = @@SESSION.innodb_lock_wait_timeout, SESSION innodb_lock_wait_timeout = "+secs)
+		restore = "SET SESSION innodb_lock_wait_timeout = @go_mysql_lock_wait_timeout, @go_mysql_lock_wait_timeout = NULL"
+	}
+
+	var characteristics []string
+	if ext.ConsistentSnapshot {
+		characteristics = append(characteristics, "WITH CONSISTENT SNAPSHOT")
+	}
+	if opts.ReadOnly {
+		characteristics = append(characteristics, "READ ONLY")
+	} else if ext.ReadWrite {
+		characteristics = append(characteristics, "READ WRITE")
+	}
+	start := "START TRANSACTION"
+	if len(characteristics) > 0 {
+		start += " " + strings.Join(characteristics, ", ")
+	}
+	queries = append(queries, start)
+
+	done := mc.traceQuery(ctx, strings.Join(queries, "; "), 0)
+	values, errs, err := mc.execPipeline(queries)
+	if err != nil {
+		done(nil, err)
+		return nil, err
+	}
+	err = firstError(errs)
+	if err == nil && wait >= 0 && string(values[wait]) == "1" {
+		err = errGTIDWaitTimeout
+	}
+	done(nil, err)
+	if err == nil {
+		mc.inTx = true
+		return &mysqlTx{mc: mc, ctx: ctx, restore: restore}, nil
+	}
+
+	// Undo the statements which succeeded.
+	var undo []string
+	if errs[len(errs)-1] == nil {
+		undo = append(undo, "ROLLBACK")
+	} else if isolation >= 0 && errs[isolation] == nil {
+		// The isolation level would apply to the next transaction.
+		mc.Close()
+		return nil, err
+	}
+	if lockWait >= 0 && errs[lockWait] == nil {
+		undo = append(undo, restore)
+	}
+	if len(undo) > 0 {
+		if _, _, err := mc.execPipeline(undo); err != nil {
+			return nil, err
+		}
+	}
+	return nil, err
+}
+
+// firstError returns the first error of errs which is not nil.
+func firstError(errs []error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+// Go MySQL Driver - A MySQL-Driver for Go's database/sql package
+//
+// Copyright 2024 The Go-MySQL-Driver Authors. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package mysql
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"strings"
+	"testing"
+	"time"
+)
+
+// pipelineReplies queues the replies of n pipelined commands, which are only
+// read after the last command has been written.
+func pipelineReplies(replies ...[]byte) [][]byte {
+	queued := make([][]byte, len(replies))
+	queued[len(replies)-1] = bytes.Join(replies, nil)
+	return queued
+}
+
+// gtidWaitReply returns the result set of WAIT_FOR_EXECUTED_GTID_SET.
+func gtidWaitReply(result byte) []byte {
+	return makePackets(1,
+		[]byte{0x01},
+		makeColumnDefinition("WAIT_FOR_EXECUTED_GTID_SET", fieldTypeLongLong),
+		[]byte{iEOF, 0x00, 0x00, 0x02, 0x00},
+		[]byte{0x01, result},
+		[]byte{iEOF, 0x00, 0x00, 0x02, 0x00},
+	)
+}
+
+func TestBeginWithTxOptions(t *testing.T) {
+	conn, mc := newRWMockConn(0)
+	conn.queuedReplies = pipelineReplies(
+		gtidWaitReply('0'),
+		makePackets(1, okPacket),
+		makePackets(1, okPacket),
+		makePackets(1, okPacket),
+	)
+
+	ctx := WithTxOptions(context.Background(), TxOptions{
+		ConsistentSnapshot:  true,
+		LockWaitTimeout:     1500 * time.Millisecond,
+		WaitForGTIDs:        "3e11fa47-71ca-11e1-9e33-c80aa9429562:1-5",
+		WaitForGTIDsTimeout: 2 * time.Second,
+	})
+	tx, err := mc.BeginTx(ctx, driver.TxOptions{Isolation: driver.IsolationLevel(sql.LevelRepeatableRead), ReadOnly: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"SELECT WAIT_FOR_EXECUTED_GTID_SET('3e11fa47-71ca-11e1-9e33-c80aa9429562:1-5', 2)",
+		"SET TRANSACTION ISOLATION LEVEL REPEATABLE READ",
+		"SESSION innodb_lock_wait_timeout = 2",
+		"START TRANSACTION WITH CONSISTENT SNAPSHOT, READ ONLY",
+	} {
+		if !bytes.Contains(conn.written, []byte(want)) {
+			t.Errorf("%q was not sent", want)
+		}
+	}
+	if !mc.inTx {
+		t.Error("transaction is not active")
+	}
+
+	conn.written = nil
+	conn.queuedReplies = pipelineReplies(makePackets(1, okPacket), makePackets(1, okPacket))
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	written := string(conn.written)
+	commit := strings.Index(written, "COMMIT")
+	restore := strings.Index(written, "SET SESSION innodb_lock_wait_timeout = @go_mysql_lock_wait_timeout")
+	if commit < 0 || restore < commit {
+		t.Errorf("expected COMMIT followed by restoring the lock wait timeout, got %q", written)
+	}
+}
+
+func TestBeginWithTxOptionsFailure(t *testing.T) {
+	conn, mc := newRWMockConn(0)
+	conn.queuedReplies = pipelineReplies(
+		gtidWaitReply('1'), // timeout
+		makePackets(1, okPacket),
+		makePackets(1, okPacket),
+	)
+	conn.queuedReplies = append(conn.queuedReplies, pipelineReplies(makePackets(1, okPacket), makePackets(1, okPacket))...)
+
+	ctx := WithTxOptions(context.Background(), TxOptions{LockWaitTimeout: time.Second, WaitForGTIDs: "uuid:1"})
+	if _, err := mc.BeginTx(ctx, driver.TxOptions{}); err != errGTIDWaitTimeout {
+		t.Fatalf("expected errGTIDWaitTimeout, got %v", err)
+	}
+	if mc.inTx {
+		t.Error("transaction is active after a failed begin")
+	}
+	written := string(conn.written)
+	start := strings.Index(written, "START TRANSACTION")
+	rollback := strings.Index(written, "ROLLBACK")
+	restore := strings.Index(written, "@go_mysql_lock_wait_timeout, @go_mysql_lock_wait_timeout = NULL")
+	if start < 0 || rollback < start || restore < rollback {
+		t.Errorf("expected the transaction to be rolled back and the session to be restored, got %q", written)
+	}
+
+	ctx = WithTxOptions(context.Background(), TxOptions{ReadWrite: true})
+	if _, err := mc.BeginTx(ctx, driver.TxOptions{ReadOnly: true}); err != errReadOnlyReadWrite {
+		t.Errorf("expected errReadOnlyReadWrite, got %v", err)
+	}
+	mc.inTx = true
+	if _, err := mc.BeginTx(ctx, driver.TxOptions{}); err != errNestedTxOptions {
+		t.Errorf("expected errNestedTxOptions, got %v", err)
+	}
+}
@@ -572,6 +572,27 @@ func skipLengthEncodedString(b []byte) (int, error) {
 	return n, io.EOF
 }
 
+// skipLengthEncodedInteger returns the number of bytes of the length encoded
+// integer at the start of b, or ErrMalformPkt if b is too short.
+func skipLengthEncodedInteger(b []byte) (int, error) {
+	if len(b) == 0 {
+		return 0, ErrMalformPkt
+	}
+	n := 1
+	switch b[0] {
+	case 0xfc:
+		n = 3
+	case 0xfd:
+		n = 4
+	case 0xfe:
+		n = 9
+	}
+	if len(b) < n {
+		return 0, ErrMalformPkt
+	}
+	return n, nil
+}
+
 // returns the number read, whether the value is NULL and the number of bytes read
 func readLengthEncodedInteger(b []byte) (uint64, bool, int) {
 	// See issue #349
@@ -774,6 +795,12 @@ func escapeStringQuotes(buf []byte, v string) []byte {
 	return buf[:pos]
 }
 
+// quoteIdentifier quotes an identifier like a table or savepoint name with
+// backticks. Backticks within the name are doubled.
+func quoteIdentifier(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
 /******************************************************************************
 *                               Sync utils                                    *
 ******************************************************************************/
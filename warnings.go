@@ -0,0 +1,108 @@
+// Go MySQL Driver - A MySQL-Driver for Go's database/sql package
+//
+// Copyright 2024 The Go-MySQL-Driver Authors. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package mysql
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+)
+
+// Warning is a row of SHOW WARNINGS.
+type Warning struct {
+	Level   string // "Note", "Warning" or "Error"
+	Code    uint16
+	Message string
+}
+
+func (w Warning) String() string {
+	return fmt.Sprintf("%s %d: %s", w.Level, w.Code, w.Message)
+}
+
+// execResult returns a copy of the result of the last statement, with its
+// warnings if they are requested by FetchWarnings or WarningsAsErrors.
+func (mc *mysqlConn) execResult() (driver.Result, error) {
+	copied := mc.result
+	copied.warningCount = mc.warningCount
+	var err error
+	if copied.warnings, err = mc.checkWarnings(copied.warningCount); err != nil {
+		return nil, err
+	}
+	return &copied, nil
+}
+
+// checkWarnings reads count warnings of the last statement if FetchWarnings
+// or WarningsAsErrors is enabled. With WarningsAsErrors, a *MySQLWarnings
+// error is returned unless all warnings are ignored.
+func (mc *mysqlConn) checkWarnings(count uint16) ([]Warning, error) {
+	cfg := mc.cfg
+	if count == 0 || !cfg.fetchWarnings && !cfg.warningsAsErrors {
+		return nil, nil
+	}
+	warnings, err := mc.showWarnings()
+	if err != nil || !cfg.warningsAsErrors {
+		return warnings, err
+	}
+
+	var failed []Warning
+	for _, w := range warnings {
+		if !slices.Contains(cfg.ignoredWarnings, w.Code) {
+			failed = append(failed, w)
+		}
+	}
+	// SHOW WARNINGS returns at most @@max_error_count warnings, the others
+	// can not be checked.
+	if len(failed) > 0 || int(count) > len(warnings) {
+		return warnings, &MySQLWarnings{Warnings: failed, Count: count}
+	}
+	return warnings, nil
+}
+
+// showWarnings reads the warnings of the last statement.
+func (mc *mysqlConn) showWarnings() ([]Warning, error) {
+	rows, err := mc.query("SHOW WARNINGS", nil)
+	if err != nil {
+		return nil, err
+	}
+	rows.noWarnings = true
+	defer rows.Close()
+
+	var warnings []Warning
+	dest := make([]driver.Value, 3)
+	for {
+		if err := rows.Next(dest); err == io.EOF {
+			return warnings, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		var w Warning
+		if b, ok := dest[0].([]byte); ok {
+			w.Level = string(b)
+		}
+		switch code := dest[1].(type) {
+		case int64:
+			w.Code = uint16(code)
+		case uint64:
+			w.Code = uint16(code)
+		case []byte:
+			n, err := strconv.ParseUint(string(code), 10, 16)
+			if err != nil {
+				return nil, fmt.Errorf("invalid warning code %q: %w", code, err)
+			}
+			w.Code = uint16(n)
+		}
+		if b, ok := dest[2].([]byte); ok {
+			w.Message = string(b)
+		}
+		warnings = append(warnings, w)
+	}
+}
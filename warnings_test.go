@@ -0,0 +1,210 @@
+// Go MySQL Driver - A MySQL-Driver for Go's database/sql package
+//
+// Copyright 2024 The Go-MySQL-Driver Authors. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package mysql
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"io"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// showWarningsReply returns the result set of SHOW WARNINGS with one row for
+// each warning.
+func showWarningsReply(warnings ...Warning) []byte {
+	payloads := [][]byte{
+		{0x03},
+		makeColumnDefinition("Level", fieldTypeVarString),
+		makeColumnDefinition("Code", fieldTypeLong),
+		makeColumnDefinition("Message", fieldTypeVarString),
+		{iEOF, 0x00, 0x00, 0x02, 0x00},
+	}
+	for _, w := range warnings {
+		var row []byte
+		row = appendLengthEncodedString(row, w.Level)
+		row = appendLengthEncodedString(row, strconv.Itoa(int(w.Code)))
+		row = appendLengthEncodedString(row, w.Message)
+		payloads = append(payloads, row)
+	}
+	payloads = append(payloads, []byte{iEOF, 0x00, 0x00, 0x02, 0x00})
+	return makePackets(1, payloads...)
+}
+
+func TestExecWarningCount(t *testing.T) {
+	conn, mc := newRWMockConn(0)
+	conn.queuedReplies = [][]byte{
+		makePackets(1, []byte{iOK, 0x01, 0x00, 0x02, 0x00, 0x02, 0x00}), // 2 warnings
+	}
+
+	res, err := mc.ExecContext(context.Background(), "INSERT INTO t VALUES ('too long')", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := res.(Result).WarningCount(); n != 2 {
+		t.Errorf("expected 2 warnings, got %d", n)
+	}
+	if w := res.(Result).Warnings(); w != nil {
+		t.Errorf("expected no warnings to be fetched, got %v", w)
+	}
+	if bytes.Contains(conn.written, []byte("SHOW WARNINGS")) {
+		t.Error("SHOW WARNINGS was sent without fetchWarnings")
+	}
+}
+
+func TestExecFetchWarnings(t *testing.T) {
+	conn, mc := newRWMockConn(0)
+	mc.cfg.fetchWarnings = true
+	want := []Warning{
+		{Level: "Warning", Code: 1265, Message: "Data truncated for column 'a' at row 1"},
+		{Level: "Note", Code: 1050, Message: "Table 't' already exists"},
+	}
+	conn.queuedReplies = [][]byte{
+		makePackets(1, []byte{iOK, 0x01, 0x07, 0x02, 0x00, 0x02, 0x00}), // insert id 7, 2 warnings
+		showWarningsReply(want...),
+	}
+
+	res, err := mc.ExecContext(context.Background(), "INSERT INTO t VALUES ('too long')", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := res.(Result).Warnings(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got warnings %v, want %v", got, want)
+	}
+	if id, _ := res.LastInsertId(); id != 7 {
+		t.Errorf("expected the insert id of the statement, got %d", id)
+	}
+	if n := res.(Result).WarningCount(); n != 2 {
+		t.Errorf("expected 2 warnings, got %d", n)
+	}
+}
+
+func TestRowsFetchWarnings(t *testing.T) {
+	conn, mc := newRWMockConn(0)
+	mc.cfg.fetchWarnings = true
+	want := []Warning{{Level: "Warning", Code: 1292, Message: "Truncated incorrect DOUBLE value: 'x'"}}
+	conn.queuedReplies = [][]byte{
+		makePackets(1,
+			[]byte{0x01},
+			makeColumnDefinition("a", fieldTypeVarString),
+			[]byte{iEOF, 0x00, 0x00, 0x02, 0x00},
+			[]byte{0x01, 'x'},
+			[]byte{iEOF, 0x01, 0x00, 0x02, 0x00}, // 1 warning
+		),
+		showWarningsReply(want...),
+	}
+
+	rows, err := mc.query("SELECT 'x' + 0", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dest := make([]driver.Value, 1)
+	if err := rows.Next(dest); err != nil {
+		t.Fatal(err)
+	}
+	if err := rows.Next(dest); err != io.EOF {
+		t.Fatalf("expected EOF, got %v", err)
+	}
+	if n := rows.WarningCount(); n != 1 {
+		t.Errorf("expected 1 warning, got %d", n)
+	}
+	if got := rows.Warnings(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got warnings %v, want %v", got, want)
+	}
+	if err := rows.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExecWarningsAsErrors(t *testing.T) {
+	conn, mc := newRWMockConn(0)
+	mc.cfg.warningsAsErrors = true
+	mc.cfg.ignoredWarnings = []uint16{1287}
+	deprecated := Warning{Level: "Warning", Code: 1287, Message: "'@@sql_mode' is deprecated"}
+	truncated := Warning{Level: "Warning", Code: 1265, Message: "Data truncated for column 'a' at row 1"}
+	conn.queuedReplies = [][]byte{
+		makePackets(1, []byte{iOK, 0x01, 0x00, 0x02, 0x00, 0x02, 0x00}),
+		showWarningsReply(deprecated, truncated),
+		makePackets(1, []byte{iOK, 0x01, 0x00, 0x02, 0x00, 0x01, 0x00}),
+		showWarningsReply(deprecated),
+	}
+
+	ctx := context.Background()
+	_, err := mc.ExecContext(ctx, "INSERT INTO t VALUES ('too long')", nil)
+	mw, ok := err.(*MySQLWarnings)
+	if !ok {
+		t.Fatalf("expected *MySQLWarnings, got %v", err)
+	}
+	if !reflect.DeepEqual(mw.Warnings, []Warning{truncated}) || mw.Count != 2 {
+		t.Errorf("unexpected warnings %+v", mw)
+	}
+	if want := "Warning 1265: Data truncated for column 'a' at row 1"; mw.Error() != want {
+		t.Errorf("got error %q, want %q", mw.Error(), want)
+	}
+
+	// only ignored warnings
+	res, err := mc.ExecContext(ctx, "SET sql_mode = ''", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := res.(Result).Warnings(); !reflect.DeepEqual(got, []Warning{deprecated}) {
+		t.Errorf("got warnings %v", got)
+	}
+}
+
+func TestStmtExecWarningsAsErrors(t *testing.T) {
+	conn, mc := newRWMockConn(0)
+	mc.cfg.warningsAsErrors = true
+	conn.queuedReplies = [][]byte{
+		makePackets(1, []byte{iOK, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}), // prepare OK, statement 1
+		makePackets(1, []byte{iOK, 0x01, 0x00, 0x02, 0x00, 0x03, 0x00}),                               // 3 warnings
+		showWarningsReply(Warning{Level: "Warning", Code: 1366, Message: "Incorrect integer value"}),
+	}
+
+	stmt, err := mc.PrepareContext(context.Background(), "INSERT INTO t VALUES ('x')")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = stmt.(*mysqlStmt).Exec(nil)
+	mw, ok := err.(*MySQLWarnings)
+	if !ok {
+		t.Fatalf("expected *MySQLWarnings, got %v", err)
+	}
+	// 2 of the warnings exceed @@max_error_count
+	if len(mw.Warnings) != 1 || mw.Count != 3 {
+		t.Errorf("unexpected warnings %+v", mw)
+	}
+}
+
+func TestRowsWarningsAsErrors(t *testing.T) {
+	conn, mc := newRWMockConn(0)
+	mc.cfg.warningsAsErrors = true
+	conn.queuedReplies = [][]byte{
+		makePackets(1,
+			[]byte{0x01},
+			makeColumnDefinition("a", fieldTypeVarString),
+			[]byte{iEOF, 0x00, 0x00, 0x02, 0x00},
+			[]byte{iEOF, 0x01, 0x00, 0x02, 0x00}, // 1 warning
+		),
+		showWarningsReply(Warning{Level: "Warning", Code: 1292, Message: "Truncated incorrect DOUBLE value: 'x'"}),
+	}
+
+	rows, err := mc.query("SELECT 'x' + 0 WHERE FALSE", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rows.Next(make([]driver.Value, 1)); err == nil || err == io.EOF {
+		t.Fatalf("expected *MySQLWarnings, got %v", err)
+	}
+	if err := rows.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
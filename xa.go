@@ -0,0 +1,280 @@
+// Go MySQL Driver - A MySQL-Driver for Go's database/sql package
+//
+// Copyright 2024 The Go-MySQL-Driver Authors. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package mysql
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// xaState is the state of the XA transaction of a connection.
+type xaState uint8
+
+const (
+	xaNone     xaState = iota
+	xaActive           // after XA START
+	xaIdle             // after XA END
+	xaPrepared         // after XA PREPARE
+)
+
+const maxXidPartLen = 64
+
+// Xid identifies a branch of an XA transaction.
+type Xid struct {
+	Gtrid    []byte // global transaction identifier, 1 to 64 bytes
+	Bqual    []byte // branch qualifier, up to 64 bytes
+	FormatID int64  // format of Gtrid and Bqual, 1 by default in MySQL
+}
+
+// String returns the xid in the syntax of XA statements, e.g.
+// X'6731',X'6231',1.
+func (x Xid) String() string {
+	return "X'" + hex.EncodeToString(x.Gtrid) + "',X'" + hex.EncodeToString(x.Bqual) + "'," + strconv.FormatInt(x.FormatID, 10)
+}
+
+func (x Xid) validate() error {
+	if len(x.Gtrid) == 0 || len(x.Gtrid) > maxXidPartLen {
+		return fmt.Errorf("invalid xid: gtrid must have 1 to %d bytes", maxXidPartLen)
+	}
+	if len(x.Bqual) > maxXidPartLen {
+		return fmt.Errorf("invalid xid: bqual must have up to %d bytes", maxXidPartLen)
+	}
+	return nil
+}
+
+// ParseXid parses an xid in the syntax of XA statements, e.g.
+// 'gtrid','bqual',1 or X'6731',X'6231',1 as returned by
+// XA RECOVER CONVERT XID. Bqual and FormatID may be omitted; FormatID
+// defaults to 1.
+func ParseXid(s string) (Xid, error) {
+	x := Xid{FormatID: 1}
+	parts, err := splitXid(s)
+	if err != nil {
+		return Xid{}, err
+	}
+	if len(parts) > 3 {
+		return Xid{}, fmt.Errorf("invalid xid %q: too many parts", s)
+	}
+	for i, part := range parts {
+		if i == 2 {
+			if x.FormatID, err = strconv.ParseInt(part, 10, 64); err != nil {
+				return Xid{}, fmt.Errorf("invalid xid %q: %w", s, err)
+			}
+			break
+		}
+		b, err := parseXidString(part)
+		if err != nil {
+			return Xid{}, fmt.Errorf("invalid xid %q: %w", s, err)
+		}
+		if i == 0 {
+			x.Gtrid = b
+		} else {
+			x.Bqual = b
+		}
+	}
+	if err := x.validate(); err != nil {
+		return Xid{}, err
+	}
+	return x, nil
+}
+
+// splitXid splits s at the commas outside of quotes.
+func splitXid(s string) ([]string, error) {
+	var parts []string
+	start, quoted := 0, false
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\'':
+			quoted = !quoted // '' within a string toggles twice
+		case ',':
+			if !quoted {
+				parts = append(parts, strings.TrimSpace(s[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	if quoted {
+		return nil, fmt.Errorf("invalid xid %q: unterminated string", s)
+	}
+	return append(parts, strings.TrimSpace(s[start:])), nil
+}
+
+// parseXidString parses a string literal, a hexadecimal literal X'..' or a
+// hexadecimal number 0x...
+func parseXidString(s string) ([]byte, error) {
+	switch {
+	case len(s) >= 3 && (s[0] == 'X' || s[0] == 'x') && s[1] == '\'' && s[len(s)-1] == '\'':
+		return hex.DecodeString(s[2 : len(s)-1])
+	case len(s) >= 3 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X'):
+		return hex.DecodeString(s[2:])
+	case len(s) >= 2 && s[0] == '\'' && s[len(s)-1] == '\'':
+		return []byte(strings.ReplaceAll(s[1:len(s)-1], "''", "'")), nil
+	}
+	return nil, fmt.Errorf("%q is not a string", s)
+}
+
+// XAError is returned for the XAER_* and XA_RB* errors of the server.
+type XAError struct {
+	Code string // e.g. "XAER_NOTA" or "XA_RBROLLBACK"
+	Err  *MySQLError
+}
+
+func (e *XAError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *XAError) Unwrap() error {
+	return e.Err
+}
+
+// RolledBack reports whether the transaction branch has been rolled back,
+// i.e. the error is one of the XA_RB* errors.
+func (e *XAError) RolledBack() bool {
+	return strings.HasPrefix(e.Code, "XA_RB")
+}
+
+// xaError wraps the XA errors of the server in an *XAError.
+func xaError(err error) error {
+	var me *MySQLError
+	if !errors.As(err, &me) {
+		return err
+	}
+	if code, ok := strings.CutPrefix(me.Name(), "ER_"); ok && (strings.HasPrefix(code, "XAER_") || strings.HasPrefix(code, "XA_RB")) {
+		return &XAError{Code: code, Err: me}
+	}
+	return err
+}
+
+// xaCommand executes the XA statement cmd for xid, followed by suffix, and
+// moves the connection to state to if it succeeds. After an XA_RB* error the
+// branch has been rolled back and the connection has no XA transaction.
+func (mc *mysqlConn) xaCommand(ctx context.Context, cmd string, xid Xid, suffix string, to xaState) error {
+	if mc.closed.Load() {
+		return driver.ErrBadConn
+	}
+	if err := xid.validate(); err != nil {
+		return err
+	}
+	if err := mc.watchCancel(ctx); err != nil {
+		return err
+	}
+	defer mc.finish()
+
+	query := cmd + " " + xid.String() + suffix
+	done := mc.traceQuery(ctx, query, 0)
+	err := mc.exec(query)
+	done(nil, err)
+	if err != nil {
+		err = xaError(err)
+		if xaErr, ok := err.(*XAError); ok && xaErr.RolledBack() {
+			mc.xaState = xaNone
+		}
+		return err
+	}
+	mc.xaState = to
+	return nil
+}
+
+// XAStart implements Conn interface.
+func (mc *mysqlConn) XAStart(ctx context.Context, xid Xid) error {
+	return mc.xaCommand(ctx, "XA START", xid, "", xaActive)
+}
+
+// XAEnd implements Conn interface.
+func (mc *mysqlConn) XAEnd(ctx context.Context, xid Xid) error {
+	return mc.xaCommand(ctx, "XA END", xid, "", xaIdle)
+}
+
+// XAPrepare implements Conn interface.
+func (mc *mysqlConn) XAPrepare(ctx context.Context, xid Xid) error {
+	return mc.xaCommand(ctx, "XA PREPARE", xid, "", xaPrepared)
+}
+
+// XACommit implements Conn interface.
+func (mc *mysqlConn) XACommit(ctx context.Context, xid Xid) error {
+	// A branch which has not been prepared is committed in one phase.
+	var suffix string
+	if mc.xaState == xaIdle {
+		suffix = " ONE PHASE"
+	}
+	return mc.xaCommand(ctx, "XA COMMIT", xid, suffix, xaNone)
+}
+
+// XARollback implements Conn interface.
+func (mc *mysqlConn) XARollback(ctx context.Context, xid Xid) error {
+	return mc.xaCommand(ctx, "XA ROLLBACK", xid, "", xaNone)
+}
+
+// XARecover implements Conn interface.
+func (mc *mysqlConn) XARecover(ctx context.Context) ([]Xid, error) {
+	if mc.closed.Load() {
+		return nil, driver.ErrBadConn
+	}
+	if err := mc.watchCancel(ctx); err != nil {
+		return nil, err
+	}
+	defer mc.finish()
+
+	done := mc.traceQuery(ctx, "XA RECOVER", 0)
+	xids, err := mc.xaRecover()
+	done(nil, err)
+	return xids, err
+}
+
+func (mc *mysqlConn) xaRecover() ([]Xid, error) {
+	rows, err := mc.query("XA RECOVER", nil)
+	if err != nil {
+		return nil, xaError(err)
+	}
+	defer rows.Close()
+
+	// formatID, gtrid_length, bqual_length, data
+	var xids []Xid
+	dest := make([]driver.Value, 4)
+	for {
+		if err := rows.Next(dest); err == io.EOF {
+			return xids, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		var nums [3]int64
+		for i := range nums {
+			if nums[i], err = xaRecoverInt(dest[i]); err != nil {
+				return nil, err
+			}
+		}
+		data, _ := dest[3].([]byte)
+		gtridLen, bqualLen := nums[1], nums[2]
+		if gtridLen < 0 || bqualLen < 0 || gtridLen+bqualLen != int64(len(data)) {
+			return nil, fmt.Errorf("invalid XA RECOVER row: %d + %d bytes of data, got %d", gtridLen, bqualLen, len(data))
+		}
+		xids = append(xids, Xid{
+			FormatID: nums[0],
+			Gtrid:    append([]byte(nil), data[:gtridLen]...),
+			Bqual:    append([]byte(nil), data[gtridLen:]...),
+		})
+	}
+}
+
+func xaRecoverInt(v driver.Value) (int64, error) {
+	switch v := v.(type) {
+	case int64:
+		return v, nil
+	case []byte:
+		return strconv.ParseInt(string(v), 10, 64)
+	}
+	return 0, fmt.Errorf("invalid XA RECOVER value %v", v)
+}
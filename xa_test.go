@@ -0,0 +1,231 @@
+// Go MySQL Driver - A MySQL-Driver for Go's database/sql package
+//
+// Copyright 2024 The Go-MySQL-Driver Authors. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package mysql
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestXidString(t *testing.T) {
+	xid := Xid{Gtrid: []byte("g1"), Bqual: []byte("b'1"), FormatID: 7}
+	if s := xid.String(); s != "X'6731',X'622731',7" {
+		t.Errorf("got %q", s)
+	}
+	parsed, err := ParseXid(xid.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(parsed, xid) {
+		t.Errorf("got %+v, want %+v", parsed, xid)
+	}
+}
+
+func TestParseXid(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Xid
+	}{
+		{"'g1'", Xid{Gtrid: []byte("g1"), FormatID: 1}},
+		{"'g,1', 'b''1', 3", Xid{Gtrid: []byte("g,1"), Bqual: []byte("b'1"), FormatID: 3}},
+		{"0x6731,x'6231',0", Xid{Gtrid: []byte("g1"), Bqual: []byte("b1"), FormatID: 0}},
+	}
+	for _, test := range tests {
+		got, err := ParseXid(test.in)
+		if err != nil {
+			t.Errorf("%q: %v", test.in, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%q: got %+v, want %+v", test.in, got, test.want)
+		}
+	}
+
+	for _, in := range []string{
+		"",
+		"''",
+		"g1",
+		"'g1",
+		"'g1','b1',x",
+		"'g1','b1',1,2",
+		"X'zz'",
+		"'" + strings.Repeat("g", maxXidPartLen+1) + "'",
+	} {
+		if _, err := ParseXid(in); err == nil {
+			t.Errorf("%q: expected error", in)
+		}
+	}
+}
+
+func TestXATransaction(t *testing.T) {
+	tracer := new(recordingTracer)
+	conn, mc := newRWMockConn(0)
+	mc.cfg.tracer = tracer
+	conn.queuedReplies = [][]byte{
+		makePackets(1, okPacket),
+		makePackets(1, okPacket),
+		makePackets(1, okPacket),
+		makePackets(1, okPacket),
+	}
+
+	ctx := context.Background()
+	xid := Xid{Gtrid: []byte("g1"), Bqual: []byte("b1"), FormatID: 1}
+	if err := mc.XAStart(ctx, xid); err != nil {
+		t.Fatal(err)
+	}
+	if mc.IsValid() {
+		t.Error("connection with an active XA transaction is valid")
+	}
+	if err := mc.XAEnd(ctx, xid); err != nil {
+		t.Fatal(err)
+	}
+	if err := mc.XAPrepare(ctx, xid); err != nil {
+		t.Fatal(err)
+	}
+	if err := mc.ResetSession(ctx); err == nil {
+		t.Error("connection with a prepared XA transaction was reset")
+	}
+	if err := mc.XACommit(ctx, xid); err != nil {
+		t.Fatal(err)
+	}
+	if !mc.IsValid() {
+		t.Error("connection is not valid after XA COMMIT")
+	}
+
+	var queries []string
+	for _, info := range tracer.done {
+		queries = append(queries, info.Query)
+	}
+	want := []string{
+		"XA START X'6731',X'6231',1",
+		"XA END X'6731',X'6231',1",
+		"XA PREPARE X'6731',X'6231',1",
+		"XA COMMIT X'6731',X'6231',1",
+	}
+	if !reflect.DeepEqual(queries, want) {
+		t.Errorf("got queries %q, want %q", queries, want)
+	}
+}
+
+func TestXACommitOnePhase(t *testing.T) {
+	conn, mc := newRWMockConn(0)
+	conn.queuedReplies = [][]byte{
+		makePackets(1, okPacket),
+		makePackets(1, okPacket),
+		makePackets(1, okPacket),
+	}
+
+	ctx := context.Background()
+	xid := Xid{Gtrid: []byte("g1")}
+	for _, f := range []func(context.Context, Xid) error{mc.XAStart, mc.XAEnd, mc.XACommit} {
+		if err := f(ctx, xid); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !bytes.Contains(conn.written, []byte("XA COMMIT X'6731',X'',0 ONE PHASE")) {
+		t.Errorf("XA COMMIT ONE PHASE was not sent: %q", conn.written)
+	}
+	if mc.xaState != xaNone {
+		t.Errorf("got XA state %d after XA COMMIT", mc.xaState)
+	}
+}
+
+func TestXAError(t *testing.T) {
+	conn, mc := newRWMockConn(0)
+	conn.queuedReplies = [][]byte{
+		makePackets(1, append([]byte{iERR, 0x75, 0x05, '#', 'X', 'A', 'E', '0', '4'}, "XAER_NOTA: Unknown XID"...)),
+	}
+
+	err := mc.XARollback(context.Background(), Xid{Gtrid: []byte("g1"), FormatID: 1})
+	var xaErr *XAError
+	if !errors.As(err, &xaErr) {
+		t.Fatalf("expected *XAError, got %T: %v", err, err)
+	}
+	if xaErr.Code != "XAER_NOTA" || xaErr.RolledBack() {
+		t.Errorf("got code %q, rolled back %v", xaErr.Code, xaErr.RolledBack())
+	}
+	var me *MySQLError
+	if !errors.As(err, &me) || me.Number != 1397 {
+		t.Errorf("expected *MySQLError 1397, got %v", err)
+	}
+
+	if err := mc.XAStart(context.Background(), Xid{}); err == nil {
+		t.Error("expected error for an empty gtrid")
+	}
+}
+
+func TestXAErrorRolledBack(t *testing.T) {
+	for _, tc := range []struct {
+		number uint16
+		code   string
+	}{
+		{1402, "XA_RBROLLBACK"},
+		{1613, "XA_RBTIMEOUT"},
+		{1614, "XA_RBDEADLOCK"},
+	} {
+		conn, mc := newRWMockConn(0)
+		errPacket := append([]byte{iERR, byte(tc.number), byte(tc.number >> 8), '#', 'X', 'A', '1', '0', '0'}, tc.code...)
+		conn.queuedReplies = [][]byte{
+			makePackets(1, okPacket),
+			makePackets(1, okPacket),
+			makePackets(1, errPacket),
+		}
+
+		ctx := context.Background()
+		xid := Xid{Gtrid: []byte("g1"), FormatID: 1}
+		if err := mc.XAStart(ctx, xid); err != nil {
+			t.Fatal(err)
+		}
+		if err := mc.XAEnd(ctx, xid); err != nil {
+			t.Fatal(err)
+		}
+		err := mc.XAPrepare(ctx, xid)
+		var xaErr *XAError
+		if !errors.As(err, &xaErr) || xaErr.Code != tc.code || !xaErr.RolledBack() {
+			t.Errorf("error %d: expected rolled back %s, got %v", tc.number, tc.code, err)
+		}
+		if mc.xaState != xaNone {
+			t.Errorf("error %d: got XA state %d", tc.number, mc.xaState)
+		}
+		if mc.busy() {
+			t.Errorf("error %d: connection is busy after the rollback", tc.number)
+		}
+	}
+}
+
+func TestXARecover(t *testing.T) {
+	conn, mc := newRWMockConn(0)
+	conn.queuedReplies = [][]byte{makePackets(1,
+		[]byte{0x04},
+		makeColumnDefinition("formatID", fieldTypeLongLong),
+		makeColumnDefinition("gtrid_length", fieldTypeLongLong),
+		makeColumnDefinition("bqual_length", fieldTypeLongLong),
+		makeColumnDefinition("data", fieldTypeVarString),
+		[]byte{iEOF, 0x00, 0x00, 0x02, 0x00},
+		[]byte{0x01, '1', 0x01, '2', 0x01, '2', 0x04, 'g', '1', 'b', '1'},
+		[]byte{0x02, '1', '2', 0x01, '3', 0x01, '0', 0x03, 'g', '2', '2'},
+		[]byte{iEOF, 0x00, 0x00, 0x02, 0x00},
+	)}
+
+	xids, err := mc.XARecover(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Xid{
+		{Gtrid: []byte("g1"), Bqual: []byte("b1"), FormatID: 1},
+		{Gtrid: []byte("g22"), FormatID: 12},
+	}
+	if !reflect.DeepEqual(xids, want) {
+		t.Errorf("got %+v, want %+v", xids, want)
+	}
+}